@@ -0,0 +1,111 @@
+// Command archivestats summarizes archived games (as written by the
+// recorder) per ruleset, snake and day: win rate, average survival turns,
+// final length, time spent in hazards and how our snake died.
+//
+//	archivestats -dir ./games
+//	archivestats -dir ./games -group ruleset -since 2021-11-01 -format csv
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+)
+
+// Output formats
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+)
+
+// dayLayout is the layout of days, both for grouping and for flags
+const dayLayout = "2006-01-02"
+
+func main() {
+	var (
+		dir     = flag.String("dir", ".", "directory of archived games (*.json.gz)")
+		format  = flag.String("format", formatTable, "output format: "+formatTable+", "+formatCSV+" or "+formatJSON)
+		group   = flag.String("group", "ruleset,snake,day", "comma separated fields to group by (ruleset, snake, day), or empty for a single summary")
+		ruleset = flag.String("ruleset", "", "only include games of this ruleset")
+		snake   = flag.String("snake", "", "only include games played by this snake")
+		since   = flag.String("since", "", "only include games that ended on or after this day ("+dayLayout+")")
+		until   = flag.String("until", "", "only include games that ended before this day ("+dayLayout+")")
+		outcome = flag.String("outcome", "", "only include games with this outcome ("+strings.Join([]string{gamerecorder.OutcomeWin, gamerecorder.OutcomeLoss, gamerecorder.OutcomeDraw, gamerecorder.OutcomeSolo}, ", ")+")")
+	)
+	flag.Parse()
+
+	keys, err := parseGroup(*group)
+	if err != nil {
+		fatal(err)
+	}
+	filter := gamerecorder.Filter{Ruleset: *ruleset, Snake: *snake, Outcome: *outcome}
+	if filter.Since, err = parseDay(*since); err != nil {
+		fatal(err)
+	}
+	if filter.Until, err = parseDay(*until); err != nil {
+		fatal(err)
+	}
+
+	s := newStats(keys)
+	err = gamerecorder.Walk(*dir, filter, func(path string, g gamerecorder.GameRecord, err error) error {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v: %v\n", filepath.Base(path), err)
+			return nil
+		}
+		s.add(summarize(g))
+		return nil
+	})
+	if err != nil {
+		fatal(err)
+	}
+
+	switch *format {
+	case formatTable:
+		err = s.writeTable(os.Stdout)
+	case formatCSV:
+		err = s.writeCSV(os.Stdout)
+	case formatJSON:
+		err = s.writeJSON(os.Stdout)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// parseGroup parses the fields to group by.
+func parseGroup(s string) ([]string, error) {
+	keys := []string{}
+	for _, k := range strings.Split(s, ",") {
+		k = strings.TrimSpace(k)
+		switch k {
+		case "":
+			continue
+		case keyRuleset, keySnake, keyDay:
+			keys = append(keys, k)
+		default:
+			return nil, fmt.Errorf("unknown group %q", k)
+		}
+	}
+	return keys, nil
+}
+
+// parseDay parses a day flag, in UTC.  Empty days are the zero time.
+func parseDay(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(dayLayout, s)
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "archivestats:", err)
+	os.Exit(1)
+}
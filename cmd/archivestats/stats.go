@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// Fields games can be grouped by
+const (
+	keyRuleset = "ruleset"
+	keySnake   = "snake"
+	keyDay     = "day"
+)
+
+// causes are the causes of death reported, in the order they're written
+// as CSV columns
+var causes = []v1.EliminationCause{
+	v1.EliminatedByOutOfBounds,
+	v1.EliminatedBySelfCollision,
+	v1.EliminatedByCollision,
+	v1.EliminatedByHeadToHead,
+	v1.EliminatedByOutOfHealth,
+	gamerecorder.EliminatedByHazard,
+	v1.EliminatedBySquad,
+	gamerecorder.EliminatedByUnknown,
+}
+
+// gameSummary is what we take from a single archived game.
+type gameSummary struct {
+	ruleset     string
+	snake       string
+	day         string
+	outcome     string
+	turns       int
+	length      int
+	hazardTurns int
+	died        bool
+	cause       v1.EliminationCause
+}
+
+// summarize summarizes an archived game.  We survive until the turn we
+// were eliminated on, or the end of the game.
+func summarize(g gamerecorder.GameRecord) gameSummary {
+	ended := g.Ended
+	if ended.IsZero() {
+		ended = g.Started
+	}
+	s := gameSummary{
+		ruleset: g.Game.Ruleset.Name,
+		snake:   g.You().Name,
+		day:     ended.UTC().Format(dayLayout),
+		outcome: g.Result(),
+	}
+	death, died := g.Eliminated()
+	if died {
+		s.died, s.cause, s.turns = true, death.Cause, death.Turn
+	}
+
+	// Walk the states we were on the board for
+	for _, d := range g.Decisions {
+		state := d.BoardState
+		you, onBoard := state.Board.Snake(state.You.ID)
+		if !onBoard {
+			continue
+		}
+		if !died {
+			s.turns = state.Turn
+		}
+		s.length = len(you.Body)
+		if state.Board.Hazards.Contains(you.Head) {
+			s.hazardTurns++
+		}
+	}
+	return s
+}
+
+// group aggregates the games sharing the grouped fields.
+type group struct {
+	Ruleset        string         `json:"ruleset,omitempty"`
+	Snake          string         `json:"snake,omitempty"`
+	Day            string         `json:"day,omitempty"`
+	Games          int            `json:"games"`
+	Wins           int            `json:"wins"`
+	Draws          int            `json:"draws"`
+	WinRate        float64        `json:"winRate"`
+	AvgTurns       float64        `json:"avgTurns"`
+	AvgLength      float64        `json:"avgLength"`
+	AvgHazardTurns float64        `json:"avgHazardTurns"`
+	Deaths         map[string]int `json:"deaths"`
+
+	turns, length, hazardTurns int
+}
+
+func (g *group) add(s gameSummary) {
+	g.Games++
+	switch s.outcome {
+	case gamerecorder.OutcomeWin:
+		g.Wins++
+	case gamerecorder.OutcomeDraw:
+		g.Draws++
+	}
+	if s.died {
+		g.Deaths[string(s.cause)]++
+	}
+	g.turns += s.turns
+	g.length += s.length
+	g.hazardTurns += s.hazardTurns
+	n := float64(g.Games)
+	g.WinRate = float64(g.Wins) / n
+	g.AvgTurns = float64(g.turns) / n
+	g.AvgLength = float64(g.length) / n
+	g.AvgHazardTurns = float64(g.hazardTurns) / n
+}
+
+// deaths describes the causes of death, most common first.
+func (g *group) deaths() string {
+	type count struct {
+		cause string
+		n     int
+	}
+	counts := []count{}
+	for c, n := range g.Deaths {
+		counts = append(counts, count{c, n})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].n != counts[j].n {
+			return counts[i].n > counts[j].n
+		}
+		return counts[i].cause < counts[j].cause
+	})
+	out := []string{}
+	for _, c := range counts {
+		out = append(out, fmt.Sprintf("%v %v", c.cause, c.n))
+	}
+	return strings.Join(out, ", ")
+}
+
+// stats aggregates game summaries by the grouped fields.
+type stats struct {
+	keys   []string
+	groups map[string]*group
+}
+
+func newStats(keys []string) *stats {
+	return &stats{keys: keys, groups: map[string]*group{}}
+}
+
+func (st *stats) has(key string) bool {
+	for _, k := range st.keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (st *stats) add(s gameSummary) {
+	var g group
+	if st.has(keyRuleset) {
+		g.Ruleset = s.ruleset
+	}
+	if st.has(keySnake) {
+		g.Snake = s.snake
+	}
+	if st.has(keyDay) {
+		g.Day = s.day
+	}
+	id := g.Day + "\x00" + g.Ruleset + "\x00" + g.Snake
+	existing, ok := st.groups[id]
+	if !ok {
+		g.Deaths = map[string]int{}
+		existing = &g
+		st.groups[id] = existing
+	}
+	existing.add(s)
+}
+
+// sorted returns the groups by day, then ruleset, then snake.
+func (st *stats) sorted() []*group {
+	groups := []*group{}
+	for _, g := range st.groups {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Ruleset != b.Ruleset {
+			return a.Ruleset < b.Ruleset
+		}
+		return a.Snake < b.Snake
+	})
+	return groups
+}
+
+// fields returns the grouped fields of the group, in the order given.
+func (st *stats) fields(g *group) []string {
+	out := []string{}
+	for _, k := range st.keys {
+		switch k {
+		case keyRuleset:
+			out = append(out, g.Ruleset)
+		case keySnake:
+			out = append(out, g.Snake)
+		case keyDay:
+			out = append(out, g.Day)
+		}
+	}
+	return out
+}
+
+func (st *stats) writeTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{}
+	for _, k := range st.keys {
+		header = append(header, strings.ToUpper(k))
+	}
+	header = append(header, "GAMES", "WINS", "DRAWS", "WIN RATE", "AVG TURNS", "AVG LENGTH", "AVG HAZARD TURNS", "DEATHS")
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, g := range st.sorted() {
+		row := append(st.fields(g),
+			strconv.Itoa(g.Games),
+			strconv.Itoa(g.Wins),
+			strconv.Itoa(g.Draws),
+			fmt.Sprintf("%.1f%%", g.WinRate*100),
+			fmt.Sprintf("%.1f", g.AvgTurns),
+			fmt.Sprintf("%.1f", g.AvgLength),
+			fmt.Sprintf("%.1f", g.AvgHazardTurns),
+			g.deaths())
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (st *stats) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := append([]string{}, st.keys...)
+	header = append(header, "games", "wins", "draws", "win_rate", "avg_turns", "avg_length", "avg_hazard_turns")
+	for _, c := range causes {
+		header = append(header, "deaths_"+string(c))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, g := range st.sorted() {
+		row := append(st.fields(g),
+			strconv.Itoa(g.Games),
+			strconv.Itoa(g.Wins),
+			strconv.Itoa(g.Draws),
+			strconv.FormatFloat(g.WinRate, 'f', 4, 64),
+			strconv.FormatFloat(g.AvgTurns, 'f', 2, 64),
+			strconv.FormatFloat(g.AvgLength, 'f', 2, 64),
+			strconv.FormatFloat(g.AvgHazardTurns, 'f', 2, 64))
+		for _, c := range causes {
+			row = append(row, strconv.Itoa(g.Deaths[string(c)]))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func (st *stats) writeJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(st.sorted())
+}
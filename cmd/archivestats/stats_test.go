@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	snek = v1.Battlesnake{
+		ID:     "me",
+		Name:   "snek",
+		Health: 100,
+		Head:   v1.Coord{X: 0, Y: 2},
+		Body:   v1.CoordList{{X: 0, Y: 2}, {X: 0, Y: 1}, {X: 0, Y: 0}},
+	}
+	opponent = v1.Battlesnake{
+		ID:     "opponent",
+		Health: 100,
+		Head:   v1.Coord{X: 4, Y: 4},
+		Body:   v1.CoordList{{X: 4, Y: 4}, {X: 4, Y: 3}, {X: 4, Y: 2}},
+	}
+	ended = time.Date(2021, 11, 2, 15, 4, 5, 0, time.UTC)
+)
+
+// archived builds an archived game from the states we saw, the last of
+// them from /end.
+func archived(ruleset string, states ...v1.BoardState) gamerecorder.GameRecord {
+	g := gamerecorder.GameRecord{
+		Game:    v1.Game{ID: "game", Ruleset: v1.Ruleset{Name: ruleset}},
+		Started: ended.Add(-time.Minute),
+		Ended:   ended,
+	}
+	for i, s := range states {
+		move := "up"
+		if i == len(states)-1 {
+			move = "end"
+		}
+		g.Decisions = append(g.Decisions, gamerecorder.Decision{BoardState: s, Decision: move})
+	}
+	return g
+}
+
+// state is a board of the given turn with us as the given snake.
+func state(turn int, you v1.Battlesnake, hazards v1.CoordList, snakes ...v1.Battlesnake) v1.BoardState {
+	return v1.BoardState{
+		Turn:  turn,
+		Board: v1.Board{Width: 5, Height: 5, Hazards: hazards, Snakes: snakes},
+		You:   you,
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	survived := archived(v1.RulesetRoyale,
+		state(0, snek, nil, snek, opponent),
+		state(1, snek, v1.CoordList{{X: 0, Y: 2}}, snek, opponent),
+		state(2, snek, nil, snek),
+	)
+	survived.Outcome = gamerecorder.OutcomeWin
+
+	killed := archived(v1.RulesetStandard,
+		state(0, snek, nil, snek, opponent),
+		state(1, snek, nil, snek, opponent),
+		state(5, snek, nil, opponent),
+	)
+	killed.Outcome = gamerecorder.OutcomeLoss
+	killed.Death = &gamerecorder.Death{Turn: 2, Cause: v1.EliminatedByHeadToHead, By: "opponent"}
+
+	// Archived before outcomes were recorded, so both are inferred
+	cornered := snek
+	cornered.Head = v1.Coord{X: 0, Y: 0}
+	cornered.Body = v1.CoordList{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}}
+	older := archived(v1.RulesetSolo,
+		state(0, cornered, nil, cornered),
+		state(1, cornered, nil),
+	)
+	older.Decisions[0].Decision = "left"
+
+	testCases := []struct {
+		desc     string
+		game     gamerecorder.GameRecord
+		expected gameSummary
+	}{
+		{
+			desc: "survived",
+			game: survived,
+			expected: gameSummary{
+				ruleset:     v1.RulesetRoyale,
+				snake:       "snek",
+				day:         "2021-11-02",
+				outcome:     gamerecorder.OutcomeWin,
+				turns:       2,
+				length:      3,
+				hazardTurns: 1,
+			},
+		},
+		{
+			desc: "eliminated",
+			game: killed,
+			expected: gameSummary{
+				ruleset: v1.RulesetStandard,
+				snake:   "snek",
+				day:     "2021-11-02",
+				outcome: gamerecorder.OutcomeLoss,
+				turns:   2,
+				length:  3,
+				died:    true,
+				cause:   v1.EliminatedByHeadToHead,
+			},
+		},
+		{
+			desc: "older archive",
+			game: older,
+			expected: gameSummary{
+				ruleset: v1.RulesetSolo,
+				snake:   "snek",
+				day:     "2021-11-02",
+				outcome: gamerecorder.OutcomeSolo,
+				turns:   1,
+				length:  3,
+				died:    true,
+				cause:   v1.EliminatedByOutOfBounds,
+			},
+		},
+		{
+			desc: "never ended",
+			game: func() gamerecorder.GameRecord {
+				g := survived
+				g.Ended = time.Time{}
+				g.Started = ended.AddDate(0, 0, -1)
+				return g
+			}(),
+			expected: gameSummary{
+				ruleset:     v1.RulesetRoyale,
+				snake:       "snek",
+				day:         "2021-11-01",
+				outcome:     gamerecorder.OutcomeWin,
+				turns:       2,
+				length:      3,
+				hazardTurns: 1,
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, summarize(tC.game))
+		})
+	}
+}
+
+// summaries are games played by two snakes over two days
+var summaries = []gameSummary{
+	{ruleset: v1.RulesetStandard, snake: "a", day: "2021-11-01", outcome: gamerecorder.OutcomeWin, turns: 100, length: 10, hazardTurns: 0},
+	{ruleset: v1.RulesetStandard, snake: "a", day: "2021-11-01", outcome: gamerecorder.OutcomeLoss, turns: 50, length: 6, hazardTurns: 2, died: true, cause: v1.EliminatedByHeadToHead},
+	{ruleset: v1.RulesetStandard, snake: "a", day: "2021-11-02", outcome: gamerecorder.OutcomeDraw, turns: 80, length: 8, hazardTurns: 0, died: true, cause: v1.EliminatedByHeadToHead},
+	{ruleset: v1.RulesetRoyale, snake: "b", day: "2021-11-02", outcome: gamerecorder.OutcomeLoss, turns: 30, length: 4, hazardTurns: 6, died: true, cause: gamerecorder.EliminatedByHazard},
+}
+
+func TestStatsGroups(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		keys     []string
+		expected []group
+	}{
+		{
+			desc: "single summary",
+			keys: []string{},
+			expected: []group{
+				{Games: 4, Wins: 1, Draws: 1, WinRate: 0.25, AvgTurns: 65, AvgLength: 7, AvgHazardTurns: 2, Deaths: map[string]int{"head-collision": 2, "hazard": 1}},
+			},
+		},
+		{
+			desc: "by ruleset",
+			keys: []string{keyRuleset},
+			expected: []group{
+				{Ruleset: v1.RulesetRoyale, Games: 1, WinRate: 0, AvgTurns: 30, AvgLength: 4, AvgHazardTurns: 6, Deaths: map[string]int{"hazard": 1}},
+				{Ruleset: v1.RulesetStandard, Games: 3, Wins: 1, Draws: 1, WinRate: 1.0 / 3, AvgTurns: 230.0 / 3, AvgLength: 8, AvgHazardTurns: 2.0 / 3, Deaths: map[string]int{"head-collision": 2}},
+			},
+		},
+		{
+			desc: "by snake and day",
+			keys: []string{keySnake, keyDay},
+			expected: []group{
+				{Snake: "a", Day: "2021-11-01", Games: 2, Wins: 1, WinRate: 0.5, AvgTurns: 75, AvgLength: 8, AvgHazardTurns: 1, Deaths: map[string]int{"head-collision": 1}},
+				{Snake: "a", Day: "2021-11-02", Games: 1, Draws: 1, AvgTurns: 80, AvgLength: 8, Deaths: map[string]int{"head-collision": 1}},
+				{Snake: "b", Day: "2021-11-02", Games: 1, AvgTurns: 30, AvgLength: 4, AvgHazardTurns: 6, Deaths: map[string]int{"hazard": 1}},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			st := newStats(tC.keys)
+			for _, s := range summaries {
+				st.add(s)
+			}
+			actual := []group{}
+			for _, g := range st.sorted() {
+				g.turns, g.length, g.hazardTurns = 0, 0, 0
+				actual = append(actual, *g)
+			}
+			assert.Equal(t, tC.expected, actual)
+		})
+	}
+}
+
+func TestStatsWrite(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		write    func(st *stats, b *bytes.Buffer) error
+		expected string
+	}{
+		{
+			desc:  "table",
+			write: func(st *stats, b *bytes.Buffer) error { return st.writeTable(b) },
+			expected: `RULESET   GAMES  WINS  DRAWS  WIN RATE  AVG TURNS  AVG LENGTH  AVG HAZARD TURNS  DEATHS
+royale    1      0     0      0.0%      30.0       4.0         6.0               hazard 1
+standard  3      1     1      33.3%     76.7       8.0         0.7               head-collision 2
+`,
+		},
+		{
+			desc:  "csv",
+			write: func(st *stats, b *bytes.Buffer) error { return st.writeCSV(b) },
+			expected: `ruleset,games,wins,draws,win_rate,avg_turns,avg_length,avg_hazard_turns,deaths_wall-collision,deaths_snake-self-collision,deaths_snake-collision,deaths_head-collision,deaths_out-of-health,deaths_hazard,deaths_squad-eliminated,deaths_unknown
+royale,1,0,0,0.0000,30.00,4.00,6.00,0,0,0,0,0,1,0,0
+standard,3,1,1,0.3333,76.67,8.00,0.67,0,0,0,2,0,0,0,0
+`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			st := newStats([]string{keyRuleset})
+			for _, s := range summaries {
+				st.add(s)
+			}
+			var b bytes.Buffer
+			assert.NoError(t, tC.write(st, &b))
+			assert.Equal(t, tC.expected, b.String())
+		})
+	}
+}
+
+func TestStatsWriteJSON(t *testing.T) {
+	st := newStats([]string{keySnake})
+	for _, s := range summaries {
+		st.add(s)
+	}
+	var b bytes.Buffer
+	assert.NoError(t, st.writeJSON(&b))
+	var groups []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &groups))
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "a", groups[0]["snake"])
+	assert.Equal(t, float64(3), groups[0]["games"])
+	assert.Equal(t, map[string]interface{}{"head-collision": float64(2)}, groups[0]["deaths"])
+	assert.Equal(t, "b", groups[1]["snake"])
+	assert.NotContains(t, groups[1], "ruleset")
+}
+
+func TestParseGroup(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		group         string
+		expected      []string
+		expectedError bool
+	}{
+		{desc: "every field", group: "ruleset,snake,day", expected: []string{keyRuleset, keySnake, keyDay}},
+		{desc: "spaces", group: " day , snake ", expected: []string{keyDay, keySnake}},
+		{desc: "single summary", group: "", expected: []string{}},
+		{desc: "unknown field", group: "ruleset,color", expectedError: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			keys, err := parseGroup(tC.group)
+			if tC.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, keys)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Command arena runs local games between snake servers, calling their
+// /start, /move and /end endpoints just as the official game engine does.
+// Moves that fail or exceed the timeout are defaulted.  The result of each
+// game is printed as JSON, and the games can be archived in the same
+// format as live games.
+//
+//	arena -snake ours=http://localhost:8080 -snake rival=http://localhost:8081/deep -games 5
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/clocklear/battlesnake/cmd/internal/gameflags"
+	"github.com/clocklear/battlesnake/lib/engine"
+)
+
+// snakeFlags collects the repeated -snake flags.
+type snakeFlags []string
+
+func (s *snakeFlags) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *snakeFlags) Set(v string) error {
+	if !strings.Contains(v, "=") {
+		return fmt.Errorf("expected name=url, got %q", v)
+	}
+	*s = append(*s, v)
+	return nil
+}
+
+func main() {
+	var snakes snakeFlags
+	flag.Var(&snakes, "snake", "a snake to play, as name=url or name@squad=url (repeatable)")
+	games := flag.Int("games", 1, "number of games to play")
+	gf := gameflags.Register(flag.CommandLine, gameflags.Defaults)
+	flag.Parse()
+	if len(snakes) == 0 {
+		fatal(fmt.Errorf("at least one -snake is required"))
+	}
+
+	rec, closeRecorder, err := gf.Recorder()
+	if err != nil {
+		fatal(err)
+	}
+	defer closeRecorder()
+
+	participants := []engine.Snake{}
+	client := &http.Client{}
+	for i, spec := range snakes {
+		name, url := split(spec, "=")
+		name, squad := split(name, "@")
+		participants = append(participants, engine.Snake{
+			ID:     fmt.Sprintf("snake-%v", i+1),
+			Name:   name,
+			Squad:  squad,
+			Player: engine.HTTPPlayer{URL: url, Client: client},
+		})
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	for i := 0; i < *games; i++ {
+		r, err := engine.Run(context.Background(), gf.Config(i), participants, rec)
+		if err != nil {
+			fatal(err)
+		}
+		if err := enc.Encode(gameResult(r, participants)); err != nil {
+			fatal(err)
+		}
+	}
+}
+
+// result is the record of a game printed by the arena, naming snakes
+// rather than using their IDs.
+type result struct {
+	engine.Result
+	Snakes map[string]string `json:"snakes"`
+}
+
+func gameResult(r engine.Result, participants []engine.Snake) result {
+	res := result{Result: r, Snakes: map[string]string{}}
+	for _, s := range participants {
+		res.Snakes[s.ID] = s.Name
+	}
+	return res
+}
+
+// split splits s around the first sep.
+func split(s, sep string) (string, string) {
+	i := strings.Index(s, sep)
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i+len(sep):]
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "arena:", err)
+	os.Exit(1)
+}
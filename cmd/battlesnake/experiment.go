@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	"github.com/clocklear/battlesnake/lib/strategy"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/newrelic/go-agent/v3/newrelic"
+)
+
+// variant is one configuration of a snake under experiment, along with
+// the results of the games it has played since the server started.
+type variant struct {
+	name   string
+	weight int
+	st     strategy.Strategy
+	so     v1.SolveOptions
+
+	mu    sync.Mutex
+	tally variantTally
+	// lastMove holds our latest move in each game in progress
+	lastMove map[string]lastMove
+}
+
+// lastMove is the turn of our latest move in a game, and when it was made.
+type lastMove struct {
+	turn int
+	at   time.Time
+}
+
+// abandonedGameAge is how long a game can go without a move before it's
+// assumed to have ended without telling us (such as when the engine
+// restarts), and is forgotten.
+const abandonedGameAge = time.Hour
+
+// variantTally counts the outcomes of the games played by a variant.
+type variantTally struct {
+	Variant string  `json:"variant"`
+	Weight  int     `json:"weight"`
+	Games   int     `json:"games"`
+	Wins    int     `json:"wins"`
+	Draws   int     `json:"draws"`
+	Losses  int     `json:"losses"`
+	WinRate float64 `json:"winRate"`
+}
+
+func newVariant(name string, weight int, strategyName string, so v1.SolveOptions) (*variant, error) {
+	if strategyName == "" {
+		strategyName = so.Algorithm
+	}
+	st, err := strategy.New(strategyName, so)
+	if err != nil {
+		return nil, err
+	}
+	return &variant{
+		name:     name,
+		weight:   weight,
+		st:       st,
+		so:       so,
+		lastMove: map[string]lastMove{},
+	}, nil
+}
+
+// variant assigns the game to one of the snake's variants.  Assignment
+// hashes the game ID, so every request for a game gets the same variant
+// without any state being kept, and variants receive a share of games in
+// proportion to their weight.
+func (s *snake) variant(gameID string) *variant {
+	if len(s.variants) == 1 {
+		return s.variants[0]
+	}
+	total := 0
+	for _, v := range s.variants {
+		total += v.weight
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(gameID))
+	n := int(h.Sum32() % uint32(total))
+	for _, v := range s.variants {
+		if n < v.weight {
+			return v
+		}
+		n -= v.weight
+	}
+	return s.variants[len(s.variants)-1]
+}
+
+// moved notes that we moved on the request's turn, so the game's outcome
+// can tell whether we were still on the board for its final turn.  Games
+// abandoned without an end request are forgotten along the way.
+func (v *variant) moved(req v1.GameRequest) {
+	now := time.Now()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.lastMove[req.Game.ID] = lastMove{turn: req.Turn, at: now}
+	for id, m := range v.lastMove {
+		if now.Sub(m.at) > abandonedGameAge {
+			delete(v.lastMove, id)
+		}
+	}
+}
+
+// record tallies the outcome of a game from its end request.
+func (v *variant) record(req v1.GameRequest) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	last, ok := v.lastMove[req.Game.ID]
+	delete(v.lastMove, req.Game.ID)
+	v.tally.Games++
+	switch gamerecorder.FinalOutcome(req.You, req.Board, ok && last.turn+1 >= req.Turn) {
+	case gamerecorder.OutcomeWin:
+		v.tally.Wins++
+	case gamerecorder.OutcomeDraw:
+		v.tally.Draws++
+	default:
+		v.tally.Losses++
+	}
+}
+
+// results returns the variant's tally so far.
+func (v *variant) results() variantTally {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	t := v.tally
+	t.Variant = v.name
+	t.Weight = v.weight
+	if t.Games > 0 {
+		t.WinRate = float64(t.Wins) / float64(t.Games)
+	}
+	return t
+}
+
+type experimentResponse struct {
+	Snake    string         `json:"snake"`
+	Variants []variantTally `json:"variants"`
+}
+
+// experiment reports the win/draw/loss tallies of each of the snake's variants.
+func (h *handler) experiment(w http.ResponseWriter, r *http.Request) {
+	txn := newrelic.FromContext(r.Context())
+	s, ok := h.snake(w, r)
+	if !ok {
+		return
+	}
+	response := experimentResponse{
+		Snake:    s.name,
+		Variants: []variantTally{},
+	}
+	for _, v := range s.variants {
+		response.Variants = append(response.Variants, v.results())
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		txn.NoticeError(err)
+		h.l.Error("failed to encode experiment response", "err", err.Error())
+	}
+}
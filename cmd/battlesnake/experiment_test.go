@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnakeVariant(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		weights []int
+	}{
+		{desc: "single variant", weights: []int{1}},
+		{desc: "even split", weights: []int{1, 1}},
+		{desc: "weighted split", weights: []int{1, 3}},
+		{desc: "three variants", weights: []int{2, 1, 1}},
+	}
+	const games = 4000
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := &snake{}
+			total := 0
+			for i, w := range tC.weights {
+				v, err := newVariant(fmt.Sprintf("v%d", i), w, "", v1.DefaultSolveOptions)
+				assert.NoError(t, err)
+				s.variants = append(s.variants, v)
+				total += w
+			}
+			counts := map[*variant]int{}
+			for i := 0; i < games; i++ {
+				id := fmt.Sprintf("game-%d", i)
+				v := s.variant(id)
+				// Every request for a game gets the same variant
+				assert.Same(t, v, s.variant(id))
+				counts[v]++
+			}
+			for _, v := range s.variants {
+				expected := float64(games) * float64(v.weight) / float64(total)
+				assert.InDelta(t, expected, float64(counts[v]), 0.1*expected, v.name)
+			}
+		})
+	}
+}
+
+func TestVariantRecord(t *testing.T) {
+	v, err := newVariant("v", 1, "", v1.DefaultSolveOptions)
+	assert.NoError(t, err)
+	me := v1.Battlesnake{ID: "me"}
+	opponent := v1.Battlesnake{ID: "opponent"}
+	end := func(game string, turn int, snakes ...v1.Battlesnake) v1.GameRequest {
+		return v1.GameRequest{Game: v1.Game{ID: game}, Turn: turn, Board: v1.Board{Snakes: snakes}, You: me}
+	}
+
+	// Won outright
+	v.moved(end("won", 9, me, opponent))
+	v.record(end("won", 10, me))
+	// Head-to-head on the final turn
+	v.moved(end("head-to-head", 9, me, opponent))
+	v.record(end("head-to-head", 10))
+	// Eliminated well before the last opponent starved
+	v.moved(end("starved", 4, me, opponent))
+	v.record(end("starved", 10))
+
+	assert.Equal(t, variantTally{Variant: "v", Weight: 1, Games: 3, Wins: 1, Draws: 1, Losses: 1, WinRate: 1.0 / 3}, v.results())
+	assert.Empty(t, v.lastMove)
+}
+
+func TestVariantMovedForgetsAbandonedGames(t *testing.T) {
+	v, err := newVariant("v", 1, "", v1.DefaultSolveOptions)
+	assert.NoError(t, err)
+	v.lastMove["abandoned"] = lastMove{turn: 20, at: time.Now().Add(-2 * abandonedGameAge)}
+	v.lastMove["quiet"] = lastMove{turn: 20, at: time.Now().Add(-abandonedGameAge / 2)}
+	v.moved(v1.GameRequest{Game: v1.Game{ID: "current"}, Turn: 3})
+	assert.Len(t, v.lastMove, 2)
+	assert.Contains(t, v.lastMove, "quiet")
+	assert.Equal(t, 3, v.lastMove["current"].turn)
+}
+
+func TestExperimentRoutes(t *testing.T) {
+	defaultSnake, err := newSnake("", BattlesnakeInfoResponse{}, "", v1.DefaultSolveOptions)
+	assert.NoError(t, err)
+	named, err := newSnake("blue", BattlesnakeInfoResponse{}, "", v1.DefaultSolveOptions)
+	assert.NoError(t, err)
+	h := &handler{snakes: map[string]*snake{"": defaultSnake, "blue": named}}
+	testCases := []struct {
+		desc     string
+		path     string
+		expected string
+	}{
+		{desc: "default snake", path: "/experiment", expected: ""},
+		{desc: "named snake", path: "/blue/experiment", expected: "blue"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router(h).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tC.path, nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			var response experimentResponse
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+			assert.Equal(t, tC.expected, response.Snake)
+			assert.Len(t, response.Variants, 1)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/clocklear/battlesnake/lib/gamerecorder"
 	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/gorilla/mux"
 	"github.com/newrelic/go-agent/v3/newrelic"
 )
 
@@ -14,7 +15,9 @@ type handler struct {
 	rec gamerecorder.GameRecorder
 	l   logger
 	nr  *newrelic.Application
-	so  v1.SolveOptions // TODO: this probably shouldn't live here
+	// snakes holds the snakes we serve by name; the default snake (served
+	// at the root) has no name
+	snakes map[string]*snake
 }
 
 type BattlesnakeInfoResponse struct {
@@ -26,19 +29,38 @@ type BattlesnakeInfoResponse struct {
 	Version    string `json:"version"`
 }
 
+// snake finds the snake addressed by the request, responding with a 404
+// if there isn't one.
+func (h *handler) snake(w http.ResponseWriter, r *http.Request) (*snake, bool) {
+	s, ok := h.snakes[mux.Vars(r)["snake"]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+	}
+	return s, ok
+}
+
+// recorderContext tags recordings with the name of the snake playing,
+// and the variant it is playing with.
+func (h *handler) recorderContext(s *snake, v *variant) context.Context {
+	ctx := context.Background()
+	if s.name != "" {
+		ctx = gamerecorder.WithTag(ctx, "snake", s.name)
+	}
+	if v.name != "" {
+		ctx = gamerecorder.WithTag(ctx, "variant", v.name)
+	}
+	return ctx
+}
+
 func (h *handler) health(w http.ResponseWriter, r *http.Request) {
 	txn := newrelic.FromContext(r.Context())
-	response := BattlesnakeInfoResponse{
-		APIVersion: "1",
-		Author:     "clocklear",
-		Color:      "#238270",
-		Head:       "silly",
-		Tail:       "coffee",
-		Version:    "1.0.1",
+	s, ok := h.snake(w, r)
+	if !ok {
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	err := json.NewEncoder(w).Encode(response)
+	err := json.NewEncoder(w).Encode(s.info)
 	if err != nil {
 		txn.NoticeError(err)
 		h.l.Error("failed to encode health response", "err", err.Error())
@@ -47,6 +69,10 @@ func (h *handler) health(w http.ResponseWriter, r *http.Request) {
 
 func (h *handler) start(w http.ResponseWriter, r *http.Request) {
 	txn := newrelic.FromContext(r.Context())
+	s, ok := h.snake(w, r)
+	if !ok {
+		return
+	}
 	request := v1.GameRequest{}
 	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
@@ -55,10 +81,10 @@ func (h *handler) start(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.rec.Start(context.Background(), request)
+	err = h.rec.Start(h.recorderContext(s, s.variant(request.Game.ID)), request)
 	if err != nil {
 		txn.NoticeError(err)
-		h.l.Error("failed to record game start", "err", err.Error(), "gameId", request.Game.ID)
+		h.l.Error("failed to record game start", "err", err.Error(), "gameId", request.Game.ID, "snake", s.name)
 	}
 
 	// Nothing to respond with here
@@ -70,51 +96,62 @@ type moveResponse struct {
 	Shout string `json:"shout,omitempty"`
 }
 
-func (h *handler) CreateMoveHandlerWithSolveOpts(opts v1.SolveOptions) func(w http.ResponseWriter, r *http.Request) {
-	return func(w http.ResponseWriter, r *http.Request) {
-		txn := newrelic.FromContext(r.Context())
-		request := v1.GameRequest{}
-		err := json.NewDecoder(r.Body).Decode(&request)
-		if err != nil {
-			h.l.Error("bad move request", "err", err.Error())
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
-		// Create a solver and use it to determine what we do next
-		s := v1.CreateSolver(request).WithLogger(h.l.base)
-
-		var resp moveResponse
-		possibleMoves, err := s.PossibleMoves(opts)
-		var move string
-		if err != nil {
-			resp.Move = "up"
-			move = "invalid"
-		} else {
-			d, _ := s.PickMove(possibleMoves, opts)
-			resp.Move = string(d)
-			move = resp.Move
-		}
-
-		// Record this move
-		err = h.rec.Move(context.Background(), request, move)
-		if err != nil {
-			txn.NoticeError(err)
-			h.l.Error("failed to record game move", "game", request.Game.ID, "turn", request.Turn, "move", resp.Move, "err", err.Error())
-		}
-		// h.l.Info("responding with move", "game", request.Game.ID, "move", resp.Move)
-
-		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(w).Encode(resp)
-		if err != nil {
-			txn.NoticeError(err)
-			h.l.Error("failed to encode move response", "err", err.Error())
-		}
+func (h *handler) move(w http.ResponseWriter, r *http.Request) {
+	txn := newrelic.FromContext(r.Context())
+	s, ok := h.snake(w, r)
+	if !ok {
+		return
+	}
+	request := v1.GameRequest{}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		h.l.Error("bad move request", "err", err.Error())
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Ask the strategy of the variant playing this game what we do next,
+	// leaving enough time for our response to reach the server
+	v := s.variant(request.Game.ID)
+	ctx, cancel := v1.WithMoveDeadline(r.Context(), request.Game, v.so.TimeoutMargin)
+	defer cancel()
+
+	var resp moveResponse
+	d, err := v.st.Move(ctx, request)
+	var move string
+	if err != nil {
+		// Continue ahead, as the engine does for a snake without a move
+		resp.Move = string(request.You.Heading(request.Board, request.Game))
+		move = gamerecorder.InvalidMove
+	} else {
+		resp.Move = string(d.Move)
+		move = resp.Move
+		h.l.LogWithLevel("debug", "decided move", "game", request.Game.ID, "turn", request.Turn, "snake", s.name, "variant", v.name, "move", move, "diagnostics", d.Diagnostics)
+	}
+
+	// Record this move
+	v.moved(request)
+	err = h.rec.Move(h.recorderContext(s, v), request, move)
+	if err != nil {
+		txn.NoticeError(err)
+		h.l.Error("failed to record game move", "game", request.Game.ID, "turn", request.Turn, "snake", s.name, "move", resp.Move, "err", err.Error())
+	}
+	// h.l.Info("responding with move", "game", request.Game.ID, "move", resp.Move)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		txn.NoticeError(err)
+		h.l.Error("failed to encode move response", "err", err.Error())
 	}
 }
 
 func (h *handler) end(w http.ResponseWriter, r *http.Request) {
 	txn := newrelic.FromContext(r.Context())
+	s, ok := h.snake(w, r)
+	if !ok {
+		return
+	}
 	request := v1.GameRequest{}
 	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
@@ -123,10 +160,13 @@ func (h *handler) end(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.rec.End(context.Background(), request)
+	v := s.variant(request.Game.ID)
+	v.record(request)
+
+	err = h.rec.End(h.recorderContext(s, v), request)
 	if err != nil {
 		txn.NoticeError(err)
-		h.l.Error("failed recording game end", "err", err.Error(), "game", request.Game.ID)
+		h.l.Error("failed recording game end", "err", err.Error(), "game", request.Game.ID, "snake", s.name)
 	}
 
 	// Nothing to respond with here
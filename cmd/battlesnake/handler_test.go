@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	"github.com/clocklear/battlesnake/lib/strategy"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/go-kit/kit/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type failingStrategy struct{}
+
+func (failingStrategy) Move(context.Context, v1.GameRequest) (strategy.Decision, error) {
+	return strategy.Decision{}, errors.New("no move")
+}
+
+// movesRecorder remembers the moves it records
+type movesRecorder struct {
+	gamerecorder.NoopGameRecorder
+	moves []string
+}
+
+func (r *movesRecorder) Move(_ context.Context, _ v1.GameRequest, move string) error {
+	r.moves = append(r.moves, move)
+	return nil
+}
+
+func TestMoveStrategyError(t *testing.T) {
+	s, err := newSnake("", BattlesnakeInfoResponse{}, "", v1.DefaultSolveOptions)
+	assert.NoError(t, err)
+	s.variants[0].st = failingStrategy{}
+	rec := &movesRecorder{}
+	h := &handler{rec: rec, l: logger{base: log.NewNopLogger()}, snakes: map[string]*snake{"": s}}
+
+	me := v1.Battlesnake{
+		ID:   "me",
+		Head: v1.Coord{X: 0, Y: 2},
+		Body: v1.CoordList{{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}},
+	}
+	body, err := json.Marshal(v1.GameRequest{
+		Game:  v1.Game{ID: "game"},
+		Turn:  10,
+		Board: v1.Board{Width: 5, Height: 5, Snakes: []v1.Battlesnake{me}},
+		You:   me,
+	})
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router(h).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/move", bytes.NewReader(body)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response moveResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+	// We continue ahead, which is what death inference replays for an
+	// invalid move
+	assert.Equal(t, string(v1.LEFT), response.Move)
+	assert.Equal(t, []string{gamerecorder.InvalidMove}, rec.moves)
+}
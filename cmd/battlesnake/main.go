@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	"github.com/clocklear/battlesnake/lib/strategy"
 	v1 "github.com/clocklear/battlesnake/lib/v1"
 	"github.com/newrelic/go-agent/v3/newrelic"
 
@@ -26,12 +27,38 @@ type config struct {
 		MaxAgeBeforePrune time.Duration `default:"2m" split_words:"true"`
 		PruneInterval     time.Duration `default:"1m" split_words:"true"`
 	} `split_words:"true"`
+	Appearance struct {
+		Author string `default:"clocklear"`
+		Color  string `default:"#238270"`
+		Head   string `default:"silly"`
+		Tail   string `default:"coffee"`
+	}
+	// Strategy names the registered strategy that decides our moves;
+	// when empty, SolveOption.Algorithm is used
+	Strategy string `default:"" split_words:"true"`
+	// SnakesFile is a JSON file describing additional snakes, served under
+	// their own path prefix
+	SnakesFile  string `default:"" split_words:"true"`
 	SolveOption struct {
-		Lookahead                bool `default:"true" split_words:"true"`
-		ConsiderOpponentNextMove bool `default:"true" split_words:"true"`
-		UseSingleBestOption      bool `default:"false" split_words:"true"`
-		FoodReward               int  `default:"20" split_words:"true"`
-		HazardPenalty            int  `default:"40" split_words:"true"`
+		Lookahead                bool          `default:"true" split_words:"true"`
+		ConsiderOpponentNextMove bool          `default:"true" split_words:"true"`
+		UseSingleBestOption      bool          `default:"false" split_words:"true"`
+		FoodReward               int           `default:"20" split_words:"true"`
+		HazardPenalty            int           `default:"40" split_words:"true"`
+		TeammatePenalty          int           `default:"10" split_words:"true"`
+		ShrinkPenalty            int           `default:"20" split_words:"true"`
+		HeadToHeadReward         int           `default:"5" split_words:"true"`
+		HeadToHeadPenalty        int           `default:"50" split_words:"true"`
+		SpacePenalty             int           `default:"60" split_words:"true"`
+		FoodSeekReward           int           `default:"30" split_words:"true"`
+		TerritoryWeight          int           `default:"20" split_words:"true"`
+		Algorithm                string        `default:"heuristic" split_words:"true"`
+		SearchDepth              int           `default:"0" split_words:"true"`
+		MCTSIterations           int           `default:"0" split_words:"true"`
+		MCTSBudget               time.Duration `default:"150ms" split_words:"true"`
+		MCTSSeed                 int64         `default:"0" split_words:"true"`
+		MCTSRollout              string        `default:"heuristic" split_words:"true"`
+		TimeoutMargin            time.Duration `default:"100ms" split_words:"true"`
 	} `split_words:"true"`
 	Logger struct {
 		Enabled bool `default:"true" split_words:"true"`
@@ -82,12 +109,36 @@ func main() {
 		gr = gamerecorder.NoopGameRecorder{}
 	}
 
+	// Create the snakes we serve, starting with the default snake
+	defaultSnake, err := newSnake("", BattlesnakeInfoResponse{
+		APIVersion: "1",
+		Author:     c.Appearance.Author,
+		Color:      c.Appearance.Color,
+		Head:       c.Appearance.Head,
+		Tail:       c.Appearance.Tail,
+		Version:    buildVersion(),
+	}, c.Strategy, v1.SolveOptions(c.SolveOption))
+	if err != nil {
+		l.Fatal("could not create strategy", "err", err.Error(), "available", strategy.Names())
+	}
+	snakes := map[string]*snake{"": defaultSnake}
+	if c.SnakesFile != "" {
+		named, err := loadSnakes(c.SnakesFile, defaultSnake)
+		if err != nil {
+			l.Fatal("could not load snakes", "err", err.Error(), "available", strategy.Names())
+		}
+		for _, s := range named {
+			snakes[s.name] = s
+			l.Info("serving snake", "snake", s.name, "path", "/"+s.name)
+		}
+	}
+
 	// Create handler
 	h := handler{
-		l:   l,
-		rec: gr,
-		nr:  nr,
-		so:  v1.SolveOptions(c.SolveOption),
+		l:      l,
+		rec:    gr,
+		nr:     nr,
+		snakes: snakes,
 	}
 
 	// Create http server
@@ -101,7 +152,7 @@ func main() {
 	// Create a channel to listen for http shutdown errors
 	errs := make(chan error, 1)
 	go func() {
-		l.Info("starting battlesnake server", "addr", c.Addr)
+		l.Info("starting battlesnake server", "addr", c.Addr, "version", buildVersion())
 		errs <- appServer.ListenAndServe()
 	}()
 
@@ -11,11 +11,20 @@ func router(h *handler) http.Handler {
 	r := mux.NewRouter()
 	r.Use(nrgorilla.Middleware(h.nr))
 
-	// Wire handlers
+	// Wire handlers for the default snake
 	r.HandleFunc("/", h.health).Methods(http.MethodGet)
 	r.HandleFunc("/start", h.start).Methods(http.MethodPost)
-	r.HandleFunc("/move", h.CreateMoveHandlerWithSolveOpts(h.so)).Methods(http.MethodPost)
+	r.HandleFunc("/move", h.move).Methods(http.MethodPost)
 	r.HandleFunc("/end", h.end).Methods(http.MethodPost)
+	r.HandleFunc("/experiment", h.experiment).Methods(http.MethodGet)
+
+	// Wire handlers for named snakes
+	r.HandleFunc("/{snake}", h.health).Methods(http.MethodGet)
+	r.HandleFunc("/{snake}/", h.health).Methods(http.MethodGet)
+	r.HandleFunc("/{snake}/start", h.start).Methods(http.MethodPost)
+	r.HandleFunc("/{snake}/move", h.move).Methods(http.MethodPost)
+	r.HandleFunc("/{snake}/end", h.end).Methods(http.MethodPost)
+	r.HandleFunc("/{snake}/experiment", h.experiment).Methods(http.MethodGet)
 
 	return r
 }
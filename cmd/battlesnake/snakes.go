@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/clocklear/battlesnake/lib/strategy"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// snake is one of the battlesnakes served by this process, each with its
+// own appearance, strategy and solve options.  Snakes running an
+// experiment play each game with one of several variants (see
+// experiment.go); other snakes have a single, unnamed variant.
+type snake struct {
+	name     string
+	info     BattlesnakeInfoResponse
+	so       v1.SolveOptions
+	variants []*variant
+}
+
+// snakesFile is the format of the file listing the snakes served under
+// their own path prefix (/{name}/move and so on).  Snakes are decoded one
+// at a time so that each starts from the defaults.
+type snakesFile struct {
+	Snakes []json.RawMessage `json:"snakes"`
+}
+
+// snakeConfig configures a single snake.  Appearance left blank is taken
+// from the default snake, and solve options left out are taken from the
+// environment.
+type snakeConfig struct {
+	Name         string                `json:"name"`
+	Author       string                `json:"author"`
+	Color        string                `json:"color"`
+	Head         string                `json:"head"`
+	Tail         string                `json:"tail"`
+	Strategy     string                `json:"strategy"`
+	SolveOptions strategy.SolveOptions `json:"solveOptions"`
+	Variants     []json.RawMessage     `json:"variants"`
+}
+
+// variantConfig configures one variant of an experiment.  Strategy and
+// solve options left out are taken from the snake.
+type variantConfig struct {
+	Name         string                `json:"name"`
+	Weight       int                   `json:"weight"`
+	Strategy     string                `json:"strategy"`
+	SolveOptions strategy.SolveOptions `json:"solveOptions"`
+}
+
+// newSnake creates a snake using the named strategy.  If no strategy is
+// named, the algorithm from the solve options is used.
+func newSnake(name string, info BattlesnakeInfoResponse, strategyName string, so v1.SolveOptions) (*snake, error) {
+	v, err := newVariant("", 1, strategyName, so)
+	if err != nil {
+		return nil, err
+	}
+	return &snake{
+		name:     name,
+		info:     info,
+		so:       so,
+		variants: []*variant{v},
+	}, nil
+}
+
+// reservedSnakeNames can't be used as snake names, as they'd clash with the
+// default snake's routes
+var reservedSnakeNames = map[string]bool{
+	"start":      true,
+	"move":       true,
+	"end":        true,
+	"experiment": true,
+}
+
+// loadSnakes reads the snakes configured in the given file.  Each snake
+// starts from the default snake's appearance and solve options.
+func loadSnakes(path string, defaults *snake) ([]*snake, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var sf snakesFile
+	if err := json.NewDecoder(f).Decode(&sf); err != nil {
+		return nil, fmt.Errorf("could not parse %v: %w", path, err)
+	}
+
+	snakes := []*snake{}
+	seen := map[string]bool{}
+	for _, raw := range sf.Snakes {
+		sc := snakeConfig{SolveOptions: strategy.SolveOptions(defaults.so)}
+		if err := json.Unmarshal(raw, &sc); err != nil {
+			return nil, fmt.Errorf("could not parse %v: %w", path, err)
+		}
+		if sc.Name == "" {
+			return nil, fmt.Errorf("snake without a name in %v", path)
+		}
+		if reservedSnakeNames[sc.Name] {
+			return nil, fmt.Errorf("snake name %v is reserved", sc.Name)
+		}
+		if seen[sc.Name] {
+			return nil, fmt.Errorf("snake %v configured twice in %v", sc.Name, path)
+		}
+		seen[sc.Name] = true
+
+		info := defaults.info
+		if sc.Author != "" {
+			info.Author = sc.Author
+		}
+		if sc.Color != "" {
+			info.Color = sc.Color
+		}
+		if sc.Head != "" {
+			info.Head = sc.Head
+		}
+		if sc.Tail != "" {
+			info.Tail = sc.Tail
+		}
+		s, err := newSnake(sc.Name, info, sc.Strategy, v1.SolveOptions(sc.SolveOptions))
+		if err != nil {
+			return nil, fmt.Errorf("snake %v: %w", sc.Name, err)
+		}
+		if len(sc.Variants) > 0 {
+			if s.variants, err = loadVariants(sc, s.so); err != nil {
+				return nil, fmt.Errorf("snake %v: %w", sc.Name, err)
+			}
+		}
+		snakes = append(snakes, s)
+	}
+	return snakes, nil
+}
+
+// loadVariants creates the variants of the snake's experiment.  Each
+// variant starts from the snake's strategy and solve options.
+func loadVariants(sc snakeConfig, so v1.SolveOptions) ([]*variant, error) {
+	variants := []*variant{}
+	seen := map[string]bool{}
+	for _, raw := range sc.Variants {
+		vc := variantConfig{Weight: 1, Strategy: sc.Strategy, SolveOptions: strategy.SolveOptions(so)}
+		if err := json.Unmarshal(raw, &vc); err != nil {
+			return nil, err
+		}
+		if vc.Name == "" {
+			return nil, fmt.Errorf("variant without a name")
+		}
+		if seen[vc.Name] {
+			return nil, fmt.Errorf("variant %v configured twice", vc.Name)
+		}
+		seen[vc.Name] = true
+		if vc.Weight < 1 {
+			return nil, fmt.Errorf("variant %v: weight must be positive", vc.Name)
+		}
+		v, err := newVariant(vc.Name, vc.Weight, vc.Strategy, v1.SolveOptions(vc.SolveOptions))
+		if err != nil {
+			return nil, fmt.Errorf("variant %v: %w", vc.Name, err)
+		}
+		variants = append(variants, v)
+	}
+	return variants, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clocklear/battlesnake/lib/strategy"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSnakes(t *testing.T) {
+	so := v1.DefaultSolveOptions
+	so.FoodReward = 99
+	defaults, err := newSnake("", BattlesnakeInfoResponse{Author: "parent", Color: "#000000", Head: "default", Tail: "default"}, "", so)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		desc          string
+		file          string
+		check         func(t *testing.T, snakes []*snake)
+		expectedError string
+	}{
+		{
+			desc: "defaults taken from the parent",
+			file: `{"snakes":[{"name":"blue","color":"#0000ff","solveOptions":{"HazardPenalty":5}}]}`,
+			check: func(t *testing.T, snakes []*snake) {
+				assert.Len(t, snakes, 1)
+				s := snakes[0]
+				assert.Equal(t, "blue", s.name)
+				assert.Equal(t, BattlesnakeInfoResponse{Author: "parent", Color: "#0000ff", Head: "default", Tail: "default"}, s.info)
+				assert.Equal(t, 99, s.so.FoodReward)
+				assert.Equal(t, 5, s.so.HazardPenalty)
+				assert.Len(t, s.variants, 1)
+			},
+		},
+		{
+			desc: "variants start from their snake",
+			file: `{"snakes":[{"name":"exp","strategy":"minimax","solveOptions":{"HazardPenalty":5},"variants":[
+				{"name":"control"},
+				{"name":"treatment","weight":3,"solveOptions":{"SearchDepth":2}}
+			]}]}`,
+			check: func(t *testing.T, snakes []*snake) {
+				assert.Len(t, snakes, 1)
+				variants := snakes[0].variants
+				assert.Len(t, variants, 2)
+				assert.Equal(t, "control", variants[0].name)
+				assert.Equal(t, 1, variants[0].weight)
+				assert.Equal(t, 5, variants[0].so.HazardPenalty)
+				assert.Equal(t, 99, variants[0].so.FoodReward)
+				assert.Equal(t, "treatment", variants[1].name)
+				assert.Equal(t, 3, variants[1].weight)
+				assert.Equal(t, 5, variants[1].so.HazardPenalty)
+				assert.Equal(t, 2, variants[1].so.SearchDepth)
+			},
+		},
+		{
+			desc:          "reserved name",
+			file:          `{"snakes":[{"name":"move"}]}`,
+			expectedError: "snake name move is reserved",
+		},
+		{
+			desc:          "missing name",
+			file:          `{"snakes":[{"color":"#ffffff"}]}`,
+			expectedError: "snake without a name",
+		},
+		{
+			desc:          "duplicate snake names",
+			file:          `{"snakes":[{"name":"blue"},{"name":"blue"}]}`,
+			expectedError: "snake blue configured twice",
+		},
+		{
+			desc:          "unknown strategy",
+			file:          `{"snakes":[{"name":"blue","strategy":"clairvoyant"}]}`,
+			expectedError: strategy.ErrUnknownStrategy.Error(),
+		},
+		{
+			desc:          "duplicate variant names",
+			file:          `{"snakes":[{"name":"exp","variants":[{"name":"a"},{"name":"a"}]}]}`,
+			expectedError: "variant a configured twice",
+		},
+		{
+			desc:          "variant without a name",
+			file:          `{"snakes":[{"name":"exp","variants":[{"weight":2}]}]}`,
+			expectedError: "variant without a name",
+		},
+		{
+			desc:          "weight less than one",
+			file:          `{"snakes":[{"name":"exp","variants":[{"name":"a","weight":0}]}]}`,
+			expectedError: "variant a: weight must be positive",
+		},
+		{
+			desc:          "malformed file",
+			file:          `{"snakes":`,
+			expectedError: "could not parse",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "snakes.json")
+			assert.NoError(t, os.WriteFile(path, []byte(tC.file), 0o644))
+			snakes, err := loadSnakes(path, defaults)
+			if tC.expectedError != "" {
+				assert.Error(t, err)
+				if err != nil {
+					assert.Contains(t, err.Error(), tC.expectedError)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			tC.check(t, snakes)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import "runtime/debug"
+
+// version is the version of this build, set at link time with
+// -ldflags "-X main.version=..." (see the Makefile and Dockerfile)
+var version = ""
+
+// shortRevision is the length VCS revisions are shortened to
+const shortRevision = 12
+
+// buildVersion reports the version of the running binary: the version set
+// at link time, or failing that the module version or VCS revision (marked
+// "-dirty" when built with local modifications) from the build info.
+func buildVersion() string {
+	if version != "" {
+		return version
+	}
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+	if bi.Main.Version != "" && bi.Main.Version != "(devel)" {
+		return bi.Main.Version
+	}
+	revision, modified := vcsRevision(bi)
+	if revision == "" {
+		return "dev"
+	}
+	if len(revision) > shortRevision {
+		revision = revision[:shortRevision]
+	}
+	if modified {
+		revision += "-dirty"
+	}
+	return revision
+}
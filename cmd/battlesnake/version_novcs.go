@@ -0,0 +1,12 @@
+//go:build !go1.18
+// +build !go1.18
+
+package main
+
+import "runtime/debug"
+
+// vcsRevision is unavailable before Go 1.18, which added VCS information
+// to the build info.
+func vcsRevision(bi *debug.BuildInfo) (revision string, modified bool) {
+	return "", false
+}
@@ -0,0 +1,137 @@
+// Package gameflags provides the command line flags shared by the tools
+// that run local games: board size, ruleset settings, timeouts and where
+// to record the games.
+package gameflags
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/clocklear/battlesnake/lib/engine"
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// Flags holds the values of the game flags once parsed.
+type Flags struct {
+	Width           int
+	Height          int
+	RulesetName     string
+	FoodSpawnChance int
+	MinimumFood     int
+	HazardDamage    int
+	ShrinkEvery     int
+	Squad           v1.SquadSettings
+	Timeout         time.Duration
+	Margin          time.Duration
+	MaxTurns        int
+	Seed            int64
+	Out             string
+	Stdout          bool
+}
+
+// Defaults holds the default values of the game flags.  Tools wanting
+// other defaults pass a modified copy to Register or RegisterSettings.
+var Defaults = Flags{
+	Width:           11,
+	Height:          11,
+	RulesetName:     v1.RulesetStandard,
+	FoodSpawnChance: 15,
+	MinimumFood:     1,
+	HazardDamage:    v1.HazardDamagePerTurn,
+	ShrinkEvery:     25,
+	Timeout:         500 * time.Millisecond,
+	Margin:          20 * time.Millisecond,
+	// Squad games play by the official rules unless told otherwise
+	Squad: v1.SquadSettings{
+		AllowBodyCollisions: true,
+		SharedElimination:   true,
+		SharedHealth:        true,
+		SharedLength:        true,
+	},
+}
+
+// Register defines the game flags on the given flag set, with the given
+// defaults.
+func Register(fs *flag.FlagSet, defaults Flags) *Flags {
+	f := RegisterSettings(fs, defaults)
+	fs.IntVar(&f.Width, "width", defaults.Width, "board width")
+	fs.IntVar(&f.Height, "height", defaults.Height, "board height")
+	fs.StringVar(&f.RulesetName, "ruleset", defaults.RulesetName, "ruleset name")
+	return f
+}
+
+// RegisterSettings defines the game flags other than the board size and
+// ruleset name, for tools that play on several boards or rulesets.  Those
+// are left at their defaults.
+func RegisterSettings(fs *flag.FlagSet, defaults Flags) *Flags {
+	f := &Flags{
+		Width:       defaults.Width,
+		Height:      defaults.Height,
+		RulesetName: defaults.RulesetName,
+	}
+	fs.IntVar(&f.FoodSpawnChance, "food-spawn-chance", defaults.FoodSpawnChance, "percent chance of spawning food each turn")
+	fs.IntVar(&f.MinimumFood, "minimum-food", defaults.MinimumFood, "minimum food on the board")
+	fs.IntVar(&f.HazardDamage, "hazard-damage", defaults.HazardDamage, "damage taken on hazards")
+	fs.IntVar(&f.ShrinkEvery, "shrink-every", defaults.ShrinkEvery, "turns between hazard shrinks (royale)")
+	fs.BoolVar(&f.Squad.AllowBodyCollisions, "squad-body-collisions", defaults.Squad.AllowBodyCollisions, "let teammates pass through each other's bodies (squad)")
+	fs.BoolVar(&f.Squad.SharedElimination, "squad-shared-elimination", defaults.Squad.SharedElimination, "eliminate a squad together (squad)")
+	fs.BoolVar(&f.Squad.SharedHealth, "squad-shared-health", defaults.Squad.SharedHealth, "share the best health across a squad (squad)")
+	fs.BoolVar(&f.Squad.SharedLength, "squad-shared-length", defaults.Squad.SharedLength, "share the longest length across a squad (squad)")
+	fs.DurationVar(&f.Timeout, "timeout", defaults.Timeout, "time allowed for each move")
+	fs.DurationVar(&f.Margin, "margin", defaults.Margin, "time in-process strategies leave unused of each move's timeout")
+	fs.IntVar(&f.MaxTurns, "max-turns", defaults.MaxTurns, "end games after this many turns (0 for no limit)")
+	fs.Int64Var(&f.Seed, "seed", defaults.Seed, "seed for the first game, incremented for each game after (0 for random)")
+	fs.StringVar(&f.Out, "out", defaults.Out, "directory to archive games to")
+	fs.BoolVar(&f.Stdout, "stdout", defaults.Stdout, "print every request and move")
+	return f
+}
+
+// Ruleset returns the ruleset described by the flags.
+func (f *Flags) Ruleset() v1.Ruleset {
+	hazardDamage := int32(f.HazardDamage)
+	return v1.Ruleset{
+		Name: f.RulesetName,
+		Settings: v1.RulesetSettings{
+			FoodSpawnChance:     f.FoodSpawnChance,
+			MinimumFood:         f.MinimumFood,
+			HazardDamagePerTurn: &hazardDamage,
+			Royale:              v1.RoyaleSettings{ShrinkEveryNTurns: f.ShrinkEvery},
+			Squad:               f.Squad,
+		},
+	}
+}
+
+// Config returns the engine configuration for the given game (numbered
+// from zero), each game getting its own seed.
+func (f *Flags) Config(game int) engine.Config {
+	cfg := engine.Config{
+		Width:    f.Width,
+		Height:   f.Height,
+		Ruleset:  f.Ruleset(),
+		Timeout:  f.Timeout,
+		MaxTurns: f.MaxTurns,
+	}
+	if f.Seed != 0 {
+		cfg.Seed = f.Seed + int64(game)
+	}
+	return cfg
+}
+
+// Recorder opens the recorder selected by the flags: a file archive in
+// the output directory, stdout, or nothing at all.  The returned function
+// must be called once recording is done.
+func (f *Flags) Recorder() (gamerecorder.GameRecorder, func(), error) {
+	switch {
+	case f.Out != "":
+		if err := os.MkdirAll(f.Out, 0755); err != nil {
+			return nil, nil, err
+		}
+		fa := gamerecorder.NewFileArchive(f.Out, time.Minute, time.Hour).(*gamerecorder.FileArchive)
+		return fa, func() { _ = fa.Shutdown() }, nil
+	case f.Stdout:
+		return gamerecorder.StdOutGameRecorder{}, func() {}, nil
+	}
+	return gamerecorder.NoopGameRecorder{}, func() {}, nil
+}
@@ -0,0 +1,257 @@
+// Command replay runs the current solver over archived games, reporting
+// every turn where it would now decide differently than it did live.
+// Recorded moves that led to our death are flagged, along with whether the
+// new move would have survived the turn (assuming the other snakes moved
+// as they did).  Strategies replay deterministically, always taking the
+// move they score best.
+//
+//	replay -dir ./games -strategy minimax
+//	replay -dir ./games -config candidate.json -only-fatal
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	"github.com/clocklear/battlesnake/lib/strategy"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+func main() {
+	var (
+		dir       = flag.String("dir", ".", "directory of archived games (*.json.gz)")
+		name      = flag.String("strategy", strategy.Heuristic, "strategy to replay with ("+strings.Join(strategy.Names(), ", ")+")")
+		config    = flag.String("config", "", "JSON file with a strategy configuration, overriding -strategy")
+		timeout   = flag.Duration("timeout", 0, "time allowed for each move (0 for each game's own timeout)")
+		onlyFatal = flag.Bool("only-fatal", false, "only report turns where the recorded move led to our death")
+		workers   = flag.Int("workers", 1, "games replayed in parallel")
+		ruleset   = flag.String("ruleset", "", "only replay games of this ruleset")
+		snakeName = flag.String("snake", "", "only replay games played by this snake")
+	)
+	flag.Parse()
+
+	st, err := loadStrategy(*name, *config)
+	if err != nil {
+		fatal(err)
+	}
+	if *workers < 1 {
+		*workers = 1
+	}
+
+	// Games are replayed by the workers as they're read, and reported in
+	// file order as each finishes, so only a few are held in memory at once
+	jobs := make(chan replayJob)
+	pending := make(chan chan replayResult, *workers)
+	var wg sync.WaitGroup
+	for w := 0; w < *workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				j.result <- replayGame(j.path, j.game, st, *timeout)
+			}
+		}()
+	}
+	var total summary
+	reported := make(chan struct{})
+	go func() {
+		defer close(reported)
+		for result := range pending {
+			r := <-result
+			for _, d := range r.diffs {
+				if *onlyFatal && !d.fatal {
+					continue
+				}
+				fmt.Println(d.String(filepath.Base(r.path)))
+			}
+			total.add(r.summary)
+		}
+	}()
+
+	filter := gamerecorder.Filter{Ruleset: *ruleset, Snake: *snakeName}
+	err = gamerecorder.Walk(*dir, filter, func(path string, g gamerecorder.GameRecord, err error) error {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v: %v\n", filepath.Base(path), err)
+			return nil
+		}
+		result := make(chan replayResult, 1)
+		pending <- result
+		jobs <- replayJob{path: path, game: g, result: result}
+		return nil
+	})
+	close(jobs)
+	close(pending)
+	wg.Wait()
+	<-reported
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Printf("\n%v games, %v states replayed: %v decisions changed, %v recorded moves were fatal (%v changed, %v of those survive)\n",
+		total.games, total.states, total.changed, total.fatal, total.fatalChanged, total.fatalSurvived)
+}
+
+// loadStrategy creates the strategy to replay with.
+func loadStrategy(name, config string) (strategy.Strategy, error) {
+	c := strategy.Config{
+		Name:         name,
+		Strategy:     name,
+		SolveOptions: strategy.SolveOptions(v1.DefaultSolveOptions),
+	}
+	if config != "" {
+		data, err := os.ReadFile(config)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("could not parse %v: %w", config, err)
+		}
+	}
+	// Replayed decisions must be reproducible: the heuristic's random pick
+	// between close moves would otherwise report changes that aren't
+	c.SolveOptions.UseSingleBestOption = true
+	if c.SolveOptions.MCTSSeed == 0 {
+		c.SolveOptions.MCTSSeed = 1
+	}
+	return c.New()
+}
+
+// summary counts what was found while replaying.
+type summary struct {
+	games         int
+	states        int
+	changed       int
+	fatal         int
+	fatalChanged  int
+	fatalSurvived int
+}
+
+func (s *summary) add(o summary) {
+	s.games += o.games
+	s.states += o.states
+	s.changed += o.changed
+	s.fatal += o.fatal
+	s.fatalChanged += o.fatalChanged
+	s.fatalSurvived += o.fatalSurvived
+}
+
+// diff is a turn where the replayed decision differs from the recorded
+// one, or where the recorded move was fatal.
+type diff struct {
+	turn      int
+	recorded  string
+	replayed  string
+	fatal     bool
+	survives  bool
+	simulated bool
+}
+
+func (d diff) String(file string) string {
+	out := fmt.Sprintf("%v turn %v: recorded %v, now %v", file, d.turn, d.recorded, d.replayed)
+	if d.fatal {
+		out += " [recorded move was fatal]"
+		if d.simulated && d.recorded != d.replayed {
+			if d.survives {
+				out += " [new move survives]"
+			} else {
+				out += " [new move also fatal]"
+			}
+		}
+	}
+	return out
+}
+
+// replayJob is an archived game waiting to be replayed, along with where
+// to send its result.
+type replayJob struct {
+	path   string
+	game   gamerecorder.GameRecord
+	result chan<- replayResult
+}
+
+type replayResult struct {
+	path    string
+	summary summary
+	diffs   []diff
+}
+
+// replayGame replays every decision recorded in the archived game.
+func replayGame(path string, g gamerecorder.GameRecord, st strategy.Strategy, timeout time.Duration) replayResult {
+	res := replayResult{path: path, summary: summary{games: 1}}
+	game := g.Game
+	if timeout > 0 {
+		game.Timeout = int32(timeout / time.Millisecond)
+	}
+	for i, d := range g.Decisions {
+		if d.Decision == "end" || i+1 >= len(g.Decisions) {
+			continue
+		}
+		state, next := d.BoardState, g.Decisions[i+1].BoardState
+		req := v1.GameRequest{Game: game, Turn: state.Turn, Board: state.Board, You: state.You}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		if game.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, time.Duration(game.Timeout)*time.Millisecond)
+		}
+		replayed := gamerecorder.InvalidMove
+		if decision, err := st.Move(ctx, req); err == nil {
+			replayed = string(decision.Move)
+		}
+		cancel()
+
+		// We died from the recorded move if we're missing from the next
+		// recorded board.  That's usually the end of the game, which may
+		// be many turns later; the turn can only be simulated when the
+		// next board is from the very next turn.
+		_, alive := next.Board.Snake(state.You.ID)
+		dd := diff{turn: state.Turn, recorded: d.Decision, replayed: replayed, fatal: !alive}
+		if dd.fatal && replayed != d.Decision && replayed != gamerecorder.InvalidMove && next.Turn == state.Turn+1 {
+			dd.survives, dd.simulated = survives(req, next.Board, v1.Direction(replayed))
+		}
+
+		res.summary.states++
+		if dd.recorded != dd.replayed {
+			res.summary.changed++
+		}
+		if dd.fatal {
+			res.summary.fatal++
+			if dd.recorded != dd.replayed {
+				res.summary.fatalChanged++
+				if dd.survives {
+					res.summary.fatalSurvived++
+				}
+			}
+		}
+		if dd.recorded != dd.replayed || dd.fatal {
+			res.diffs = append(res.diffs, dd)
+		}
+	}
+	return res
+}
+
+// survives determines if we'd have survived the turn making the given
+// move, with every other snake making the move it was recorded making.
+// Snakes that were eliminated that turn are assumed to have continued
+// ahead.  ok is false if the turn couldn't be simulated.
+func survives(req v1.GameRequest, next v1.Board, move v1.Direction) (alive bool, ok bool) {
+	moves, ok := gamerecorder.InferMoves(req.Board, next, req.Game)
+	if !ok {
+		return false, false
+	}
+	moves[req.You.ID] = move
+	_, eliminated := req.Board.Elimination(req.You.ID, moves, req.Game)
+	return !eliminated, true
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "replay:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,116 @@
+// Command selfplay runs complete local games between in-process
+// strategies, recording them in the same formats as live games.
+//
+//	selfplay -snakes heuristic,minimax,mcts -games 10 -ruleset royale -out ./games
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/clocklear/battlesnake/cmd/internal/gameflags"
+	"github.com/clocklear/battlesnake/lib/engine"
+	"github.com/clocklear/battlesnake/lib/strategy"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+func main() {
+	var (
+		snakes = flag.String("snakes", "heuristic,heuristic", "comma separated strategies, one per snake ("+strings.Join(strategy.Names(), ", ")+")")
+		squads = flag.String("squads", "", "comma separated squad for each snake (squad games)")
+		games  = flag.Int("games", 1, "number of games to play")
+		gf     = gameflags.Register(flag.CommandLine, gameflags.Defaults)
+	)
+	flag.Parse()
+
+	names := strings.Split(*snakes, ",")
+	squadNames := []string{}
+	if *squads != "" {
+		squadNames = strings.Split(*squads, ",")
+		if len(squadNames) != len(names) {
+			fatal(fmt.Errorf("%v squads given for %v snakes", len(squadNames), len(names)))
+		}
+	}
+
+	rec, closeRecorder, err := gf.Recorder()
+	if err != nil {
+		fatal(err)
+	}
+	defer closeRecorder()
+
+	wins := map[string]int{}
+	draws := 0
+	for i := 0; i < *games; i++ {
+		participants := []engine.Snake{}
+		for j, name := range names {
+			st, err := strategy.New(name, v1.DefaultSolveOptions)
+			if err != nil {
+				fatal(err)
+			}
+			s := engine.Snake{
+				ID:     fmt.Sprintf("snake-%v", j+1),
+				Name:   fmt.Sprintf("%v-%v", name, j+1),
+				Player: engine.StrategyPlayer{Strategy: st, Margin: gf.Margin},
+			}
+			if len(squadNames) > 0 {
+				s.Squad = squadNames[j]
+			}
+			participants = append(participants, s)
+		}
+
+		r, err := engine.Run(context.Background(), gf.Config(i), participants, rec)
+		if err != nil {
+			fatal(err)
+		}
+		printResult(r, participants)
+		if len(r.Survivors) == 0 {
+			draws++
+		}
+		for _, id := range r.Survivors {
+			wins[nameOf(id, participants)]++
+		}
+	}
+
+	fmt.Printf("\n%v games, %v draws\n", *games, draws)
+	for j, name := range names {
+		n := fmt.Sprintf("%v-%v", name, j+1)
+		fmt.Printf("%-20v %v survived\n", n, wins[n])
+	}
+}
+
+// printResult summarizes a game.
+func printResult(r engine.Result, participants []engine.Snake) {
+	survivors := []string{}
+	for _, id := range r.Survivors {
+		survivors = append(survivors, nameOf(id, participants))
+	}
+	fmt.Printf("game %v: %v turns, survivors: [%v]\n", r.GameID, r.Turns, strings.Join(survivors, ", "))
+	for _, e := range r.Eliminations {
+		by := ""
+		if e.By != "" && e.By != e.ID {
+			by = " by " + nameOf(e.By, participants)
+		}
+		fmt.Printf("  turn %v: %v eliminated (%v%v)\n", e.Turn, nameOf(e.ID, participants), e.Cause, by)
+	}
+	for id, n := range r.DefaultedMoves {
+		fmt.Printf("  %v: %v moves defaulted\n", nameOf(id, participants), n)
+	}
+}
+
+// nameOf returns the name of the snake with the given ID.
+func nameOf(id string, participants []engine.Snake) string {
+	for _, s := range participants {
+		if s.ID == id {
+			return s.Name
+		}
+	}
+	return id
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "selfplay:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,179 @@
+// Command tournament ranks strategy configurations by playing duels
+// between them locally, across rulesets and board sizes, and printing an
+// Elo leaderboard with confidence intervals.
+//
+//	tournament -entrants heuristic,minimax,mcts -format swiss -rulesets standard,royale -sizes 11x11,7x7
+//	tournament -config entrants.json -games 10 -workers 8
+//
+// The configuration file lists named strategy configurations:
+//
+//	{"entrants": [
+//	  {"name": "control", "strategy": "heuristic"},
+//	  {"name": "deep", "strategy": "minimax", "solveOptions": {"SearchDepth": 6}}
+//	]}
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/clocklear/battlesnake/cmd/internal/gameflags"
+	"github.com/clocklear/battlesnake/lib/strategy"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// Tournament formats
+const (
+	formatRoundRobin = "roundrobin"
+	formatSwiss      = "swiss"
+)
+
+// bootstrapSamples is the number of resamples used to estimate the
+// confidence intervals of ratings
+const bootstrapSamples = 200
+
+func main() {
+	var (
+		config   = flag.String("config", "", "JSON file of named strategy configurations")
+		entrants = flag.String("entrants", "", "comma separated strategies to enter with default options ("+strings.Join(strategy.Names(), ", ")+")")
+		format   = flag.String("format", formatRoundRobin, "tournament format: "+formatRoundRobin+" or "+formatSwiss)
+		rounds   = flag.Int("rounds", 0, "round robin cycles, or swiss rounds (0 for 1 cycle, or enough swiss rounds to find a winner)")
+		games    = flag.Int("games", 2, "games per pairing on each ruleset and board size")
+		rulesets = flag.String("rulesets", v1.RulesetStandard, "comma separated rulesets to play")
+		sizes    = flag.String("sizes", "11x11", "comma separated board sizes to play, as WIDTHxHEIGHT")
+		workers  = flag.Int("workers", runtime.NumCPU(), "games played in parallel")
+	)
+	// Strategies that stall each other would otherwise play forever
+	defaults := gameflags.Defaults
+	defaults.MaxTurns = 1000
+	gf := gameflags.RegisterSettings(flag.CommandLine, defaults)
+	flag.Parse()
+
+	t := tournament{
+		games:   *games,
+		workers: *workers,
+		flags:   gf,
+	}
+	var err error
+	if t.entrants, err = loadEntrants(*config, *entrants); err != nil {
+		fatal(err)
+	}
+	if len(t.entrants) < 2 {
+		fatal(fmt.Errorf("at least two entrants are required"))
+	}
+	if t.boards, err = parseBoards(*rulesets, *sizes); err != nil {
+		fatal(err)
+	}
+	t.seed = gf.Seed
+	if t.seed == 0 {
+		t.seed = time.Now().UnixNano()
+	}
+
+	rec, closeRecorder, err := gf.Recorder()
+	if err != nil {
+		fatal(err)
+	}
+	defer closeRecorder()
+	t.rec = rec
+
+	ctx := context.Background()
+	switch *format {
+	case formatRoundRobin:
+		if *rounds <= 0 {
+			*rounds = 1
+		}
+		err = t.roundRobin(ctx, *rounds)
+	case formatSwiss:
+		if *rounds <= 0 {
+			*rounds = swissRounds(len(t.entrants))
+		}
+		err = t.swiss(ctx, *rounds)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		fatal(err)
+	}
+
+	t.printLeaderboard(os.Stdout, rand.New(rand.NewSource(t.seed)))
+}
+
+// loadEntrants reads the entrants from the configuration file, or creates
+// them from a list of strategy names.
+func loadEntrants(path, names string) ([]entrant, error) {
+	configs := []strategy.Config{}
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		var file struct {
+			Entrants []json.RawMessage `json:"entrants"`
+		}
+		if err := json.NewDecoder(f).Decode(&file); err != nil {
+			return nil, fmt.Errorf("could not parse %v: %w", path, err)
+		}
+		for _, raw := range file.Entrants {
+			c := strategy.Config{SolveOptions: strategy.SolveOptions(v1.DefaultSolveOptions)}
+			if err := json.Unmarshal(raw, &c); err != nil {
+				return nil, fmt.Errorf("could not parse %v: %w", path, err)
+			}
+			configs = append(configs, c)
+		}
+	}
+	if names != "" {
+		for _, name := range strings.Split(names, ",") {
+			configs = append(configs, strategy.Config{
+				Name:         name,
+				Strategy:     name,
+				SolveOptions: strategy.SolveOptions(v1.DefaultSolveOptions),
+			})
+		}
+	}
+
+	entrants := []entrant{}
+	seen := map[string]bool{}
+	for _, c := range configs {
+		if c.Name == "" {
+			return nil, fmt.Errorf("entrant without a name")
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("entrant %v entered twice", c.Name)
+		}
+		seen[c.Name] = true
+		st, err := c.New()
+		if err != nil {
+			return nil, fmt.Errorf("entrant %v: %w", c.Name, err)
+		}
+		entrants = append(entrants, entrant{name: c.Name, st: st})
+	}
+	return entrants, nil
+}
+
+// parseBoards returns every combination of the given rulesets and sizes.
+func parseBoards(rulesets, sizes string) ([]board, error) {
+	boards := []board{}
+	for _, size := range strings.Split(sizes, ",") {
+		var w, h int
+		if _, err := fmt.Sscanf(size, "%dx%d", &w, &h); err != nil || w < 1 || h < 1 {
+			return nil, fmt.Errorf("bad board size %q", size)
+		}
+		for _, rs := range strings.Split(rulesets, ",") {
+			boards = append(boards, board{ruleset: rs, width: w, height: h})
+		}
+	}
+	return boards, nil
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "tournament:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/clocklear/battlesnake/cmd/internal/gameflags"
+	"github.com/clocklear/battlesnake/lib/engine"
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	"github.com/clocklear/battlesnake/lib/rating"
+	"github.com/clocklear/battlesnake/lib/strategy"
+)
+
+// entrant is a named strategy configuration taking part in the tournament.
+type entrant struct {
+	name string
+	st   strategy.Strategy
+}
+
+// board is a ruleset and board size that every pairing plays on.
+type board struct {
+	ruleset string
+	width   int
+	height  int
+}
+
+// match is a single game between two entrants (by index).
+type match struct {
+	a, b  int
+	board board
+	seed  int64
+}
+
+// outcome is the result of a match.  Score is a's share of the points.
+type outcome struct {
+	match
+	score float64
+	turns int
+}
+
+type tournament struct {
+	entrants []entrant
+	boards   []board
+	games    int
+	workers  int
+	seed     int64
+	flags    *gameflags.Flags
+	rec      gamerecorder.GameRecorder
+
+	matches  int
+	outcomes []outcome
+}
+
+// roundRobin pairs every entrant with every other, the given number of
+// times.
+func (t *tournament) roundRobin(ctx context.Context, cycles int) error {
+	matches := []match{}
+	for c := 0; c < cycles; c++ {
+		for a := range t.entrants {
+			for b := a + 1; b < len(t.entrants); b++ {
+				matches = append(matches, t.pairing(a, b)...)
+			}
+		}
+	}
+	outcomes, err := t.play(ctx, matches)
+	t.outcomes = append(t.outcomes, outcomes...)
+	return err
+}
+
+// swissRounds is the number of Swiss rounds needed to separate the given
+// number of entrants.
+func swissRounds(entrants int) int {
+	return int(math.Ceil(math.Log2(float64(entrants))))
+}
+
+// swiss plays the given number of rounds, each round pairing entrants
+// with similar scores who haven't met yet.  With an odd number of
+// entrants, the lowest scoring entrant without one gets a bye, worth a
+// win in every game.
+func (t *tournament) swiss(ctx context.Context, rounds int) error {
+	points := make([]float64, len(t.entrants))
+	met := map[[2]int]bool{}
+	byes := map[int]bool{}
+	for round := 0; round < rounds; round++ {
+		// Order by points, breaking ties by entry order
+		order := make([]int, len(t.entrants))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool { return points[order[i]] > points[order[j]] })
+
+		if len(order)%2 == 1 {
+			for i := len(order) - 1; i >= 0; i-- {
+				if !byes[order[i]] || i == 0 {
+					byes[order[i]] = true
+					points[order[i]] += float64(t.games * len(t.boards))
+					order = append(order[:i], order[i+1:]...)
+					break
+				}
+			}
+		}
+
+		matches := []match{}
+		paired := map[int]bool{}
+		for i, a := range order {
+			if paired[a] {
+				continue
+			}
+			// The next unpaired entrant not yet met, or failing that the
+			// next unpaired entrant
+			opponent := -1
+			for _, b := range order[i+1:] {
+				if paired[b] {
+					continue
+				}
+				if opponent < 0 {
+					opponent = b
+				}
+				if !met[[2]int{a, b}] {
+					opponent = b
+					break
+				}
+			}
+			if opponent < 0 {
+				continue
+			}
+			paired[a], paired[opponent] = true, true
+			met[[2]int{a, opponent}], met[[2]int{opponent, a}] = true, true
+			matches = append(matches, t.pairing(a, opponent)...)
+		}
+
+		outcomes, err := t.play(ctx, matches)
+		if err != nil {
+			return err
+		}
+		for _, o := range outcomes {
+			points[o.a] += o.score
+			points[o.b] += 1 - o.score
+		}
+		t.outcomes = append(t.outcomes, outcomes...)
+	}
+	return nil
+}
+
+// pairing returns the matches played between two entrants: the given
+// number of games on every board, alternating which is listed first.
+func (t *tournament) pairing(a, b int) []match {
+	matches := []match{}
+	for _, bd := range t.boards {
+		for g := 0; g < t.games; g++ {
+			m := match{a: a, b: b, board: bd, seed: t.seed + int64(t.matches)}
+			if g%2 == 1 {
+				m.a, m.b = b, a
+			}
+			t.matches++
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// play runs the matches using the tournament's workers, returning the
+// outcomes in the same order.
+func (t *tournament) play(ctx context.Context, matches []match) ([]outcome, error) {
+	outcomes := make([]outcome, len(matches))
+	errs := make([]error, len(matches))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	workers := t.workers
+	if workers < 1 {
+		workers = 1
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				outcomes[i], errs[i] = t.playMatch(ctx, matches[i])
+			}
+		}()
+	}
+	for i := range matches {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return outcomes, nil
+}
+
+// playMatch plays a single game.  The entrant that survives wins; if
+// both survive (the turn limit was reached) or neither does, it's a draw.
+func (t *tournament) playMatch(ctx context.Context, m match) (outcome, error) {
+	cfg := t.flags.Config(0)
+	cfg.Width, cfg.Height = m.board.width, m.board.height
+	cfg.Ruleset.Name = m.board.ruleset
+	cfg.Seed = m.seed
+	snakes := []engine.Snake{
+		{ID: "a", Name: t.entrants[m.a].name, Player: engine.StrategyPlayer{Strategy: t.entrants[m.a].st, Margin: t.flags.Margin}},
+		{ID: "b", Name: t.entrants[m.b].name, Player: engine.StrategyPlayer{Strategy: t.entrants[m.b].st, Margin: t.flags.Margin}},
+	}
+	r, err := engine.Run(ctx, cfg, snakes, t.rec)
+	if err != nil {
+		return outcome{}, err
+	}
+	o := outcome{match: m, score: 0.5, turns: r.Turns}
+	if winner, ok := r.Winner(); ok {
+		o.score = 0
+		if winner == "a" {
+			o.score = 1
+		}
+	}
+	return o, nil
+}
+
+// standing is an entrant's line on the leaderboard.
+type standing struct {
+	name                string
+	rating              float64
+	interval            rating.Interval
+	wins, draws, losses int
+	turns               int
+}
+
+func (s standing) games() int {
+	return s.wins + s.draws + s.losses
+}
+
+// printLeaderboard prints the entrants ranked by rating.
+func (t *tournament) printLeaderboard(w io.Writer, r *rand.Rand) {
+	games := []rating.Game{}
+	standings := map[string]*standing{}
+	for _, e := range t.entrants {
+		standings[e.name] = &standing{name: e.name, rating: rating.Base}
+	}
+	for _, o := range t.outcomes {
+		a, b := standings[t.entrants[o.a].name], standings[t.entrants[o.b].name]
+		games = append(games, rating.Game{A: a.name, B: b.name, Score: o.score})
+		a.turns += o.turns
+		b.turns += o.turns
+		switch o.score {
+		case 1:
+			a.wins++
+			b.losses++
+		case 0:
+			a.losses++
+			b.wins++
+		default:
+			a.draws++
+			b.draws++
+		}
+	}
+	ratings := rating.Fit(games)
+	intervals := map[string]rating.Interval{}
+	if len(games) > 0 {
+		intervals = rating.Bootstrap(games, bootstrapSamples, 0.95, r)
+	}
+
+	ranked := []*standing{}
+	for _, s := range standings {
+		if rt, ok := ratings[s.name]; ok {
+			s.rating = rt
+		}
+		s.interval = intervals[s.name]
+		ranked = append(ranked, s)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].rating != ranked[j].rating {
+			return ranked[i].rating > ranked[j].rating
+		}
+		return ranked[i].name < ranked[j].name
+	})
+
+	fmt.Fprintf(w, "%v games\n\n", len(t.outcomes))
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tENTRANT\tELO\t95% CI\tGAMES\tW\tD\tL\tSCORE\tAVG TURNS")
+	for i, s := range ranked {
+		score, turns := 0.0, 0.0
+		if s.games() > 0 {
+			score = (float64(s.wins) + float64(s.draws)/2) / float64(s.games()) * 100
+			turns = float64(s.turns) / float64(s.games())
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%.0f\t%.0f - %.0f\t%v\t%v\t%v\t%v\t%.1f%%\t%.1f\n",
+			i+1, s.name, s.rating, s.interval.Low, s.interval.High, s.games(), s.wins, s.draws, s.losses, score, turns)
+	}
+	_ = tw.Flush()
+}
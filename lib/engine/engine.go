@@ -0,0 +1,268 @@
+// Package engine runs complete Battlesnake games locally, resolving each
+// turn with the rules implemented in lib/v1.  Snakes are controlled by
+// Players, which may decide in process or call out to a snake server.
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/clocklear/battlesnake/lib/gamerecorder"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+var (
+	// ErrNoSnakes indicates that a game was started without any snakes
+	ErrNoSnakes = fmt.Errorf("no snakes")
+	// ErrTooManySnakes indicates that the snakes don't fit on the board
+	ErrTooManySnakes = fmt.Errorf("too many snakes for the board")
+)
+
+// Config describes a game to be run by the engine.
+type Config struct {
+	// GameID identifies the game; one is generated when empty
+	GameID string
+	Width  int
+	Height int
+	// Ruleset holds the ruleset name and settings (food, hazards, ...)
+	Ruleset v1.Ruleset
+	// Timeout is the time each player is given to make a move
+	Timeout time.Duration
+	// MaxTurns ends the game after the given number of turns; zero plays
+	// until the game is decided
+	MaxTurns int
+	// Seed seeds the placement of snakes, food and hazards; zero uses the
+	// current time
+	Seed int64
+}
+
+// Snake is a participant in a game.
+type Snake struct {
+	ID     string
+	Name   string
+	Squad  string
+	Player Player
+}
+
+// Elimination records a snake eliminated during the game.
+type Elimination struct {
+	Turn int `json:"turn"`
+	v1.Elimination
+}
+
+// Result is the outcome of a game.
+type Result struct {
+	GameID string `json:"gameId"`
+	// Turns is the number of turns played
+	Turns int `json:"turns"`
+	// Survivors lists the IDs of the snakes on the final board
+	Survivors    []string      `json:"survivors"`
+	Eliminations []Elimination `json:"eliminations"`
+	// DefaultedMoves counts, by snake ID, the moves that were defaulted
+	// because the player failed to respond in time (or at all)
+	DefaultedMoves map[string]int `json:"defaultedMoves"`
+	Board          v1.Board       `json:"board"`
+}
+
+// Winner returns the ID of the only surviving snake, if there is one.
+func (r Result) Winner() (string, bool) {
+	if len(r.Survivors) != 1 {
+		return "", false
+	}
+	return r.Survivors[0], true
+}
+
+// Run plays a complete game between the given snakes.  Each turn every
+// living snake's player is asked for a move concurrently; players that
+// fail, or don't answer within the timeout, continue in the direction
+// they're heading.  Failed start and end requests are ignored.  Every
+// request and move is sent to the recorder (if any) from the perspective
+// of the snake involved, just as the server would record a live game.
+func Run(ctx context.Context, cfg Config, snakes []Snake, rec gamerecorder.GameRecorder) (Result, error) {
+	if len(snakes) == 0 {
+		return Result{}, ErrNoSnakes
+	}
+	if rec == nil {
+		rec = gamerecorder.NoopGameRecorder{}
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(seed))
+
+	game := v1.Game{
+		ID:      cfg.GameID,
+		Ruleset: cfg.Ruleset,
+		Timeout: int32(cfg.Timeout / time.Millisecond),
+	}
+	if game.ID == "" {
+		game.ID = fmt.Sprintf("local-%016x", r.Int63())
+	}
+	board := v1.Board{
+		Width:   cfg.Width,
+		Height:  cfg.Height,
+		Food:    v1.CoordList{},
+		Hazards: v1.CoordList{},
+	}
+	if err := placeSnakes(&board, snakes, r); err != nil {
+		return Result{}, err
+	}
+	placeFood(&board, game, r)
+
+	result := Result{
+		GameID:         game.ID,
+		Eliminations:   []Elimination{},
+		DefaultedMoves: map[string]int{},
+	}
+	players := map[string]Player{}
+	last := map[string]v1.Battlesnake{}
+	for i, s := range snakes {
+		players[s.ID] = s.Player
+		last[s.ID] = board.Snakes[i]
+	}
+
+	// Start the game
+	turn := 0
+	for _, s := range board.Snakes {
+		req := v1.GameRequest{Game: game, Turn: turn, Board: board, You: s}
+		notify(ctx, cfg.Timeout, req, players[s.ID].Start)
+		if err := rec.Start(ctx, req); err != nil {
+			return Result{}, err
+		}
+	}
+
+	for !over(board, len(snakes)) && (cfg.MaxTurns <= 0 || turn < cfg.MaxTurns) {
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		moves := map[string]v1.Direction{}
+		for _, m := range collectMoves(ctx, game, turn, board, players, cfg.Timeout) {
+			move := string(m.move)
+			if m.err != nil {
+				m.move = m.req.You.Heading(board, game)
+				move = gamerecorder.InvalidMove
+				result.DefaultedMoves[m.req.You.ID]++
+			}
+			moves[m.req.You.ID] = m.move
+			if err := rec.Move(ctx, m.req, move); err != nil {
+				return Result{}, err
+			}
+		}
+
+		next, eliminations := board.Step(moves, game)
+		turn++
+		for _, e := range eliminations {
+			result.Eliminations = append(result.Eliminations, Elimination{Turn: turn, Elimination: e})
+		}
+		next = next.ShrinkHazards(game, turn, r)
+		board = next.SpawnFood(game, r)
+		for _, s := range board.Snakes {
+			last[s.ID] = s
+		}
+	}
+
+	// End the game for everyone, whether or not they survived
+	for _, s := range snakes {
+		req := v1.GameRequest{Game: game, Turn: turn, Board: board, You: last[s.ID]}
+		notify(ctx, cfg.Timeout, req, players[s.ID].End)
+		if err := rec.End(ctx, req); err != nil {
+			return Result{}, err
+		}
+	}
+
+	result.Turns = turn
+	result.Board = board
+	result.Survivors = []string{}
+	for _, s := range board.Snakes {
+		result.Survivors = append(result.Survivors, s.ID)
+	}
+	return result, nil
+}
+
+// over determines if the game is decided: every snake is gone, or (in
+// games that began with more than one snake) a single snake or squad
+// remains.
+func over(b v1.Board, players int) bool {
+	if len(b.Snakes) == 0 {
+		return true
+	}
+	if players == 1 {
+		return false
+	}
+	for _, s := range b.Snakes[1:] {
+		if !s.IsTeammate(b.Snakes[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// notify sends a start or end request to a player.  As with the official
+// engine, players aren't required to respond, so failures are ignored.
+func notify(ctx context.Context, timeout time.Duration, req v1.GameRequest, f func(context.Context, v1.GameRequest) error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	_ = f(ctx, req)
+}
+
+// playerMove is a player's answer to a move request.
+type playerMove struct {
+	req  v1.GameRequest
+	move v1.Direction
+	err  error
+}
+
+// errMoveTimeout indicates that a player didn't respond in time
+var errMoveTimeout = fmt.Errorf("move timed out")
+
+// collectMoves asks every snake on the board for its move concurrently,
+// giving each player until the timeout (if any) to respond.  Moves are
+// returned in board order.
+func collectMoves(ctx context.Context, g v1.Game, turn int, b v1.Board, players map[string]Player, timeout time.Duration) []playerMove {
+	moves := make([]playerMove, len(b.Snakes))
+	var wg sync.WaitGroup
+	for i, s := range b.Snakes {
+		// Each player gets its own copy of the board, so nothing it does
+		// can affect the others
+		moves[i].req = v1.GameRequest{Game: g, Turn: turn, Board: b.Clone(), You: s}
+		wg.Add(1)
+		go func(m *playerMove, p Player) {
+			defer wg.Done()
+			mctx, cancel := context.WithCancel(ctx)
+			if timeout > 0 {
+				mctx, cancel = context.WithTimeout(ctx, timeout)
+			}
+			defer cancel()
+
+			// Players that overrun the deadline are abandoned
+			answer := make(chan playerMove, 1)
+			go func() {
+				d, err := p.Move(mctx, m.req)
+				answer <- playerMove{move: d, err: err}
+			}()
+			select {
+			case a := <-answer:
+				m.move, m.err = a.move, a.err
+			case <-mctx.Done():
+				m.err = errMoveTimeout
+			}
+			if m.err == nil && !validDirection(m.move) {
+				m.err = fmt.Errorf("invalid move %q", m.move)
+			}
+		}(&moves[i], players[s.ID])
+	}
+	wg.Wait()
+	return moves
+}
+
+// validDirection determines if the direction is one of the four moves.
+func validDirection(d v1.Direction) bool {
+	return d == v1.UP || d == v1.DOWN || d == v1.LEFT || d == v1.RIGHT
+}
@@ -0,0 +1,158 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedPlayer always makes the same move, optionally after a delay.
+type fixedPlayer struct {
+	move  v1.Direction
+	delay time.Duration
+}
+
+func (p fixedPlayer) Start(context.Context, v1.GameRequest) error {
+	return nil
+}
+
+func (p fixedPlayer) Move(ctx context.Context, req v1.GameRequest) (v1.Direction, error) {
+	select {
+	case <-time.After(p.delay):
+		return p.move, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (p fixedPlayer) End(context.Context, v1.GameRequest) error {
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	standard := v1.Ruleset{Name: v1.RulesetStandard}
+	testCases := []struct {
+		desc              string
+		cfg               Config
+		snakes            []Snake
+		expectedTurns     int
+		expectedSurvivors []string
+		expectedCauses    []v1.EliminationCause
+		expectedDefaulted map[string]int
+		expectedError     error
+	}{
+		{
+			desc:          "no snakes",
+			cfg:           Config{Width: 7, Height: 7, Ruleset: standard, Seed: 1},
+			expectedError: ErrNoSnakes,
+		},
+		{
+			desc: "too many snakes",
+			cfg:  Config{Width: 1, Height: 1, Ruleset: standard, Seed: 1},
+			snakes: []Snake{
+				{ID: "a", Player: fixedPlayer{move: v1.UP}},
+				{ID: "b", Player: fixedPlayer{move: v1.UP}},
+			},
+			expectedError: ErrTooManySnakes,
+		},
+		{
+			desc: "solo snake runs into a wall",
+			cfg:  Config{Width: 7, Height: 7, Ruleset: v1.Ruleset{Name: v1.RulesetSolo}, Seed: 1},
+			snakes: []Snake{
+				{ID: "a", Player: fixedPlayer{move: v1.UP}},
+			},
+			expectedSurvivors: []string{},
+			expectedCauses:    []v1.EliminationCause{v1.EliminatedByOutOfBounds},
+			expectedDefaulted: map[string]int{},
+		},
+		{
+			desc: "stops at the turn limit",
+			cfg:  Config{Width: 7, Height: 7, Ruleset: standard, Seed: 1, MaxTurns: 1},
+			snakes: []Snake{
+				{ID: "a", Player: fixedPlayer{move: v1.UP}},
+				{ID: "b", Player: fixedPlayer{move: v1.DOWN}},
+			},
+			expectedTurns:     1,
+			expectedSurvivors: []string{"a", "b"},
+			expectedCauses:    []v1.EliminationCause{},
+			expectedDefaulted: map[string]int{},
+		},
+		{
+			desc: "defaults moves that time out",
+			cfg:  Config{Width: 7, Height: 7, Ruleset: standard, Seed: 1, MaxTurns: 1, Timeout: 10 * time.Millisecond},
+			snakes: []Snake{
+				{ID: "a", Player: fixedPlayer{move: v1.UP}},
+				{ID: "b", Player: fixedPlayer{move: v1.DOWN, delay: time.Second}},
+			},
+			expectedTurns:     1,
+			expectedSurvivors: []string{"a", "b"},
+			expectedCauses:    []v1.EliminationCause{},
+			expectedDefaulted: map[string]int{"b": 1},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual, err := Run(context.Background(), tC.cfg, tC.snakes, nil)
+			if tC.expectedError != nil {
+				assert.ErrorIs(t, err, tC.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			if tC.expectedTurns > 0 {
+				assert.Equal(t, tC.expectedTurns, actual.Turns)
+			}
+			assert.ElementsMatch(t, tC.expectedSurvivors, actual.Survivors)
+			causes := []v1.EliminationCause{}
+			for _, e := range actual.Eliminations {
+				causes = append(causes, e.Cause)
+			}
+			assert.Equal(t, tC.expectedCauses, causes)
+			assert.Equal(t, tC.expectedDefaulted, actual.DefaultedMoves)
+		})
+	}
+}
+
+func TestPlaceSnakes(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		width     int
+		height    int
+		snakes    int
+		positions v1.CoordList
+	}{
+		{
+			desc:      "corners first on standard boards",
+			width:     11,
+			height:    11,
+			snakes:    4,
+			positions: fixedStartPositions(11, 11).First(4),
+		},
+		{
+			desc:      "edges once the corners are taken",
+			width:     11,
+			height:    11,
+			snakes:    8,
+			positions: fixedStartPositions(11, 11),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			snakes := []Snake{}
+			for i := 0; i < tC.snakes; i++ {
+				snakes = append(snakes, Snake{ID: string(rune('a' + i))})
+			}
+			b := v1.Board{Width: tC.width, Height: tC.height}
+			assert.NoError(t, placeSnakes(&b, snakes, rand.New(rand.NewSource(1))))
+			heads := v1.CoordList{}
+			for _, s := range b.Snakes {
+				assert.Len(t, s.Body, startingLength)
+				heads = append(heads, s.Head)
+			}
+			assert.ElementsMatch(t, tC.positions, heads)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// HTTPPlayer plays by calling the /start, /move and /end endpoints of a
+// snake server, as the official game engine does.
+type HTTPPlayer struct {
+	// URL is the base URL of the snake, e.g. http://localhost:8080 or
+	// http://localhost:8080/variant
+	URL string
+	// Client makes the requests; http.DefaultClient is used when nil.
+	// Move deadlines are enforced by the engine through the context.
+	Client *http.Client
+}
+
+func (p HTTPPlayer) Start(ctx context.Context, req v1.GameRequest) error {
+	return p.post(ctx, "start", req, nil)
+}
+
+func (p HTTPPlayer) Move(ctx context.Context, req v1.GameRequest) (v1.Direction, error) {
+	var resp struct {
+		Move string `json:"move"`
+	}
+	if err := p.post(ctx, "move", req, &resp); err != nil {
+		return "", err
+	}
+	return v1.Direction(strings.ToLower(resp.Move)), nil
+}
+
+func (p HTTPPlayer) End(ctx context.Context, req v1.GameRequest) error {
+	return p.post(ctx, "end", req, nil)
+}
+
+// post sends the request to the given endpoint, decoding the response
+// into out (if given).
+func (p HTTPPlayer) post(ctx context.Context, endpoint string, req v1.GameRequest, out interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	url := strings.TrimSuffix(p.URL, "/") + "/" + endpoint
+	hr, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	hr.Header.Set("Content-Type", "application/json")
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(hr)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v responded with %v", url, resp.Status)
+	}
+	if out == nil {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
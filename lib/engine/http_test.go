@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPPlayer(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		status        int
+		body          string
+		expected      v1.Direction
+		expectedError bool
+	}{
+		{
+			desc:     "valid move",
+			status:   http.StatusOK,
+			body:     `{"move":"left"}`,
+			expected: v1.LEFT,
+		},
+		{
+			desc:     "moves are case insensitive",
+			status:   http.StatusOK,
+			body:     `{"move":"Up"}`,
+			expected: v1.UP,
+		},
+		{
+			desc:          "server error",
+			status:        http.StatusInternalServerError,
+			expectedError: true,
+		},
+		{
+			desc:          "garbage response",
+			status:        http.StatusOK,
+			body:          `not json`,
+			expectedError: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			paths := []string{}
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				var req v1.GameRequest
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "game", req.Game.ID)
+				w.WriteHeader(tC.status)
+				_, _ = w.Write([]byte(tC.body))
+			}))
+			defer srv.Close()
+
+			p := HTTPPlayer{URL: srv.URL + "/snake/"}
+			actual, err := p.Move(context.Background(), v1.GameRequest{Game: v1.Game{ID: "game"}})
+			assert.Equal(t, []string{"/snake/move"}, paths)
+			if tC.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, actual)
+		})
+	}
+}
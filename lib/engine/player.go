@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"context"
+	"time"
+
+	"github.com/clocklear/battlesnake/lib/strategy"
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// Player makes the moves for a snake in a game run by the engine.  The
+// methods mirror the Battlesnake API's /start, /move and /end requests.
+type Player interface {
+	Start(context.Context, v1.GameRequest) error
+	Move(context.Context, v1.GameRequest) (v1.Direction, error)
+	End(context.Context, v1.GameRequest) error
+}
+
+// StrategyPlayer plays in process using a Strategy.
+type StrategyPlayer struct {
+	Strategy strategy.Strategy
+	// Margin is taken off the game's timeout when the strategy is given
+	// its deadline, so that a strategy using all of its time still answers
+	// before the engine stops waiting
+	Margin time.Duration
+}
+
+func (p StrategyPlayer) Start(context.Context, v1.GameRequest) error {
+	return nil
+}
+
+func (p StrategyPlayer) Move(ctx context.Context, req v1.GameRequest) (v1.Direction, error) {
+	ctx, cancel := v1.WithMoveDeadline(ctx, req.Game, p.Margin)
+	defer cancel()
+	d, err := p.Strategy.Move(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return d.Move, nil
+}
+
+func (p StrategyPlayer) End(context.Context, v1.GameRequest) error {
+	return nil
+}
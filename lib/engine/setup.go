@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"math/rand"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// startingLength is the length of every snake at the start of a game
+const startingLength = 3
+
+// placeSnakes puts the snakes on the board, stacked on their starting
+// square.  Boards large enough use the official fixed starting positions
+// (corners first, then the middle of each edge), assigned at random;
+// otherwise snakes start on random unoccupied squares.
+func placeSnakes(b *v1.Board, snakes []Snake, r *rand.Rand) error {
+	positions := fixedStartPositions(b.Width, b.Height)
+	if len(positions) > 0 && len(snakes) <= len(positions) {
+		// Corners are preferred, so shuffle them separately from the edges
+		corners, edges := positions[:4], positions[4:]
+		r.Shuffle(len(corners), func(i, j int) { corners[i], corners[j] = corners[j], corners[i] })
+		r.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+	} else {
+		positions = v1.CoordList{}
+		for x := 0; x < b.Width; x++ {
+			for y := 0; y < b.Height; y++ {
+				positions = append(positions, v1.Coord{X: x, Y: y})
+			}
+		}
+		if len(snakes) > len(positions) {
+			return ErrTooManySnakes
+		}
+		r.Shuffle(len(positions), func(i, j int) { positions[i], positions[j] = positions[j], positions[i] })
+	}
+
+	for i, s := range snakes {
+		body := v1.CoordList{}
+		for j := 0; j < startingLength; j++ {
+			body = append(body, positions[i])
+		}
+		b.Snakes = append(b.Snakes, v1.Battlesnake{
+			ID:     s.ID,
+			Name:   s.Name,
+			Squad:  s.Squad,
+			Health: v1.MaximumSnakeHealth,
+			Body:   body,
+			Head:   positions[i],
+			Length: startingLength,
+		})
+	}
+	return nil
+}
+
+// fixedStartPositions returns the official starting positions for boards
+// of at least 7x7 with odd dimensions: one square in from each corner,
+// then the middle of each edge.  Other boards have none.
+func fixedStartPositions(width, height int) v1.CoordList {
+	if width < 7 || height < 7 || width%2 == 0 || height%2 == 0 {
+		return v1.CoordList{}
+	}
+	minX, midX, maxX := 1, (width-1)/2, width-2
+	minY, midY, maxY := 1, (height-1)/2, height-2
+	return v1.CoordList{
+		{X: minX, Y: minY},
+		{X: minX, Y: maxY},
+		{X: maxX, Y: minY},
+		{X: maxX, Y: maxY},
+		{X: minX, Y: midY},
+		{X: midX, Y: minY},
+		{X: maxX, Y: midY},
+		{X: midX, Y: maxY},
+	}
+}
+
+// placeFood puts the starting food on the board: one piece on a free
+// square diagonal to each snake (away from the center where possible),
+// and one in the center.  Constrictor games have no food.
+func placeFood(b *v1.Board, g v1.Game, r *rand.Rand) {
+	if g.Ruleset.Name == v1.RulesetConstrictor {
+		return
+	}
+	center := v1.Coord{X: (b.Width - 1) / 2, Y: (b.Height - 1) / 2}
+	taken := func(c v1.Coord) bool {
+		if b.Food.Contains(c) || (c.X == center.X && c.Y == center.Y) {
+			return true
+		}
+		for _, s := range b.Snakes {
+			if s.Body.Contains(c) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, s := range b.Snakes {
+		options := v1.CoordList{}
+		for _, d := range []v1.Coord{{X: -1, Y: -1}, {X: -1, Y: 1}, {X: 1, Y: -1}, {X: 1, Y: 1}} {
+			c := v1.Coord{X: s.Head.X + d.X, Y: s.Head.Y + d.Y}
+			if !c.WithinBounds(*b) || taken(c) {
+				continue
+			}
+			options = append(options, c)
+		}
+		// Prefer squares that don't lead toward the center
+		away := v1.CoordList{}
+		for _, c := range options {
+			if c.ManhattanDistanceOnBoard(center, *b, g) >= s.Head.ManhattanDistanceOnBoard(center, *b, g) {
+				away = append(away, c)
+			}
+		}
+		if len(away) > 0 {
+			options = away
+		}
+		if len(options) > 0 {
+			b.Food = append(b.Food, options[r.Intn(len(options))])
+		}
+	}
+	if !taken(center) {
+		b.Food = append(b.Food, center)
+	}
+}
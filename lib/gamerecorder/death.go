@@ -0,0 +1,139 @@
+package gamerecorder
+
+import (
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// Causes of death beyond those reported by the rules engine
+const (
+	// EliminatedByHazard is reported when our snake ran out of health with
+	// its head on a hazard; starving elsewhere is v1.EliminatedByOutOfHealth
+	EliminatedByHazard v1.EliminationCause = "hazard"
+	// EliminatedByUnknown is reported when we know we were eliminated, but
+	// replaying our last move doesn't explain how
+	EliminatedByUnknown v1.EliminationCause = "unknown"
+)
+
+// Death describes how our snake was eliminated.
+type Death struct {
+	Turn  int                 `json:"turn"`
+	Cause v1.EliminationCause `json:"cause"`
+	By    string              `json:"by,omitempty"`
+}
+
+// Eliminated returns how our snake was eliminated.  ok is false if we were
+// still on the board when the game ended.  Games archived before deaths
+// were recorded have theirs inferred.
+func (g GameRecord) Eliminated() (Death, bool) {
+	if g.Outcome != "" {
+		if g.Death == nil {
+			return Death{}, false
+		}
+		return *g.Death, true
+	}
+	return g.inferDeath()
+}
+
+// inferDeath infers how our snake was eliminated by replaying the last move
+// we made.  ok is false if we were still on the board when the game ended.
+//
+// Other snakes are assumed to have made the moves that took them to the
+// final board when it was recorded on the very next turn, and to have
+// carried on ahead otherwise.  If that doesn't eliminate us, a
+// head-to-head collision with any snake that could have reached our square
+// is considered.
+func (g GameRecord) inferDeath() (d Death, ok bool) {
+	n := len(g.Decisions)
+	if n == 0 {
+		return Death{}, false
+	}
+	final := g.Decisions[n-1].BoardState
+	you := final.You
+	if n > 1 {
+		you = g.Decisions[n-2].BoardState.You
+	}
+	if _, alive := final.Board.Snake(you.ID); alive {
+		return Death{}, false
+	}
+	if n == 1 {
+		return Death{Turn: final.Turn, Cause: EliminatedByUnknown}, true
+	}
+
+	last := g.Decisions[n-2]
+	state := last.BoardState
+	moves := map[string]v1.Direction{}
+	if final.Turn == state.Turn+1 {
+		moves, _ = InferMoves(state.Board, final.Board, g.Game)
+	}
+	// An InvalidMove continued in the direction we were heading
+	move := you.Heading(state.Board, g.Game)
+	if isDirection(last.Decision) {
+		move = v1.Direction(last.Decision)
+	}
+	moves[you.ID] = move
+	d = Death{Turn: state.Turn + 1, Cause: EliminatedByUnknown}
+	if e, eliminated := state.Board.Elimination(you.ID, moves, g.Game); eliminated {
+		d.Cause, d.By = e.Cause, e.By
+	} else if e, eliminated := headToHead(you, move, state.Board, moves, g.Game); eliminated {
+		d.Cause, d.By = e.Cause, e.By
+	}
+	if d.Cause == v1.EliminatedByOutOfHealth {
+		if h, _ := you.Head.ProjectOnBoard(move, state.Board, g.Game); state.Board.Hazards.Contains(h) {
+			d.Cause = EliminatedByHazard
+		}
+	}
+	return d, true
+}
+
+// InferMoves determines the moves that took the snakes on a board to the
+// next board, which must be from the very next turn.  Snakes missing from
+// the next board were eliminated, and are left out.  ok is false if the
+// move of a snake still on the next board couldn't be determined; the
+// moves that could be are returned regardless.
+func InferMoves(b, next v1.Board, g v1.Game) (moves map[string]v1.Direction, ok bool) {
+	moves = map[string]v1.Direction{}
+	ok = true
+	for _, s := range b.Snakes {
+		after, found := next.Snake(s.ID)
+		if !found {
+			continue
+		}
+		if m, inferred := s.Head.DirectionTo(after.Head, b, g); inferred {
+			moves[s.ID] = m
+		} else {
+			ok = false
+		}
+	}
+	return moves, ok
+}
+
+// headToHead looks for a snake whose move is unknown that could have met
+// us head on, eliminating us.
+func headToHead(you v1.Battlesnake, move v1.Direction, b v1.Board, moves map[string]v1.Direction, g v1.Game) (v1.Elimination, bool) {
+	target, _ := you.Head.ProjectOnBoard(move, b, g)
+	for _, s := range b.Snakes {
+		if _, known := moves[s.ID]; known {
+			continue
+		}
+		d, reaches := s.Head.DirectionTo(target, b, g)
+		if !reaches {
+			continue
+		}
+		alt := map[string]v1.Direction{s.ID: d}
+		for id, m := range moves {
+			alt[id] = m
+		}
+		if e, eliminated := b.Elimination(you.ID, alt, g); eliminated {
+			return e, true
+		}
+	}
+	return v1.Elimination{}, false
+}
+
+func isDirection(move string) bool {
+	switch v1.Direction(move) {
+	case v1.UP, v1.DOWN, v1.LEFT, v1.RIGHT:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package gamerecorder
+
+import (
+	"testing"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// record builds a game record from our last move and the final board.
+func record(you v1.Battlesnake, board v1.Board, move string, final v1.Board, finalTurn int) GameRecord {
+	board.Width, board.Height = 5, 5
+	final.Width, final.Height = 5, 5
+	return GameRecord{
+		Game: v1.Game{Ruleset: v1.Ruleset{Name: v1.RulesetStandard}},
+		Decisions: []Decision{
+			{BoardState: v1.BoardState{Turn: 10, Board: board, You: you}, Decision: move},
+			{BoardState: v1.BoardState{Turn: finalTurn, Board: final, You: you}, Decision: "end"},
+		},
+	}
+}
+
+func TestGameRecordDeath(t *testing.T) {
+	me := v1.Battlesnake{
+		ID:     "me",
+		Health: 50,
+		Head:   v1.Coord{X: 0, Y: 2},
+		Body:   v1.CoordList{{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}},
+	}
+	curled := v1.Battlesnake{
+		ID:     "me",
+		Health: 50,
+		Head:   v1.Coord{X: 1, Y: 1},
+		Body:   v1.CoordList{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 1}, {X: 2, Y: 0}},
+	}
+	starving := me
+	starving.Health = 1
+	weak := me
+	weak.Health = 10
+	opponent := v1.Battlesnake{
+		ID:     "opponent",
+		Health: 100,
+		Head:   v1.Coord{X: 0, Y: 4},
+		Body:   v1.CoordList{{X: 0, Y: 4}, {X: 1, Y: 4}, {X: 2, Y: 4}, {X: 3, Y: 4}},
+	}
+	testCases := []struct {
+		desc     string
+		game     GameRecord
+		expected Death
+		died     bool
+	}{
+		{
+			desc: "survived",
+			game: record(me, v1.Board{Snakes: []v1.Battlesnake{me}}, "up", v1.Board{Snakes: []v1.Battlesnake{me}}, 11),
+		},
+		{
+			desc:     "wall",
+			game:     record(me, v1.Board{Snakes: []v1.Battlesnake{me}}, "left", v1.Board{}, 11),
+			expected: Death{Turn: 11, Cause: v1.EliminatedByOutOfBounds},
+			died:     true,
+		},
+		{
+			desc:     "self",
+			game:     record(curled, v1.Board{Snakes: []v1.Battlesnake{curled}}, "right", v1.Board{}, 11),
+			expected: Death{Turn: 11, Cause: v1.EliminatedBySelfCollision, By: "me"},
+			died:     true,
+		},
+		{
+			desc:     "starvation",
+			game:     record(starving, v1.Board{Snakes: []v1.Battlesnake{starving}}, "up", v1.Board{}, 11),
+			expected: Death{Turn: 11, Cause: v1.EliminatedByOutOfHealth},
+			died:     true,
+		},
+		{
+			desc:     "hazard",
+			game:     record(weak, v1.Board{Snakes: []v1.Battlesnake{weak}, Hazards: v1.CoordList{{X: 0, Y: 3}}}, "up", v1.Board{}, 11),
+			expected: Death{Turn: 11, Cause: EliminatedByHazard},
+			died:     true,
+		},
+		{
+			desc: "head-to-head with a longer snake, ended much later",
+			game: record(me, v1.Board{Snakes: []v1.Battlesnake{me, opponent}}, "up",
+				v1.Board{Snakes: []v1.Battlesnake{opponent}}, 40),
+			expected: Death{Turn: 11, Cause: v1.EliminatedByHeadToHead, By: "opponent"},
+			died:     true,
+		},
+		{
+			desc: "head-to-head with the opponent's move inferred from the next turn",
+			game: record(me, v1.Board{Snakes: []v1.Battlesnake{me, opponent}}, "up",
+				v1.Board{Snakes: []v1.Battlesnake{{
+					ID:   "opponent",
+					Head: v1.Coord{X: 0, Y: 3},
+					Body: v1.CoordList{{X: 0, Y: 3}, {X: 0, Y: 4}, {X: 1, Y: 4}, {X: 2, Y: 4}},
+				}}}, 11),
+			expected: Death{Turn: 11, Cause: v1.EliminatedByHeadToHead, By: "opponent"},
+			died:     true,
+		},
+		{
+			desc:     "invalid move continues ahead",
+			game:     record(me, v1.Board{Snakes: []v1.Battlesnake{me}}, InvalidMove, v1.Board{}, 11),
+			expected: Death{Turn: 11, Cause: v1.EliminatedByOutOfBounds},
+			died:     true,
+		},
+		{
+			desc:     "unexplained",
+			game:     record(me, v1.Board{Snakes: []v1.Battlesnake{me}}, "up", v1.Board{}, 11),
+			expected: Death{Turn: 11, Cause: EliminatedByUnknown},
+			died:     true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual, died := tC.game.Eliminated()
+			assert.Equal(t, tC.died, died)
+			assert.Equal(t, tC.expected, actual)
+		})
+	}
+}
+
+func TestInferMoves(t *testing.T) {
+	a := v1.Battlesnake{ID: "a", Head: v1.Coord{X: 5, Y: 5}, Body: v1.CoordList{{X: 5, Y: 5}, {X: 5, Y: 4}}}
+	b := v1.Battlesnake{ID: "b", Head: v1.Coord{X: 8, Y: 8}, Body: v1.CoordList{{X: 8, Y: 8}, {X: 8, Y: 7}}}
+	board := v1.Board{Width: 11, Height: 11, Snakes: []v1.Battlesnake{a, b}}
+	moved := func(s v1.Battlesnake, head v1.Coord) v1.Battlesnake {
+		s.Head = head
+		return s
+	}
+	testCases := []struct {
+		desc       string
+		next       []v1.Battlesnake
+		expected   map[string]v1.Direction
+		expectedOk bool
+	}{
+		{
+			desc:       "every snake moved",
+			next:       []v1.Battlesnake{moved(a, v1.Coord{X: 4, Y: 5}), moved(b, v1.Coord{X: 8, Y: 9})},
+			expected:   map[string]v1.Direction{"a": v1.LEFT, "b": v1.UP},
+			expectedOk: true,
+		},
+		{
+			desc:       "eliminated snakes are left out",
+			next:       []v1.Battlesnake{moved(b, v1.Coord{X: 9, Y: 8})},
+			expected:   map[string]v1.Direction{"b": v1.RIGHT},
+			expectedOk: true,
+		},
+		{
+			desc:     "boards that aren't a turn apart",
+			next:     []v1.Battlesnake{moved(a, v1.Coord{X: 3, Y: 5}), moved(b, v1.Coord{X: 8, Y: 9})},
+			expected: map[string]v1.Direction{"b": v1.UP},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			moves, ok := InferMoves(board, v1.Board{Width: 11, Height: 11, Snakes: tC.next}, v1.Game{})
+			assert.Equal(t, tC.expected, moves)
+			assert.Equal(t, tC.expectedOk, ok)
+		})
+	}
+}
@@ -17,30 +17,46 @@ import (
 // in the given path
 type FileArchive struct {
 	basePath          string
-	games             map[string]game
+	games             map[string]GameRecord
 	maxAgeBeforePrune time.Duration
 	pruneInterval     time.Duration
 	quit              chan int
 	mu                sync.RWMutex
 }
 
-type decision struct {
+// Decision is a board state we were asked to move from, and the move we
+// made.  The final state of a game (from /end) has the decision "end".
+type Decision struct {
 	BoardState v1.BoardState `json:"state"`
 	Decision   string        `json:"decision"`
 }
 
-type game struct {
-	Game       v1.Game    `json:"game"`
-	Decisions  []decision `json:"states"`
-	Started    time.Time  `json:"startedAt"`
-	Ended      time.Time  `json:"endedAt"`
-	Won        bool       `json:"won"`
+// InvalidMove is the decision recorded when no move was decided (the
+// strategy failed or ran out of time).  The snake continues in the
+// direction it is heading.
+const InvalidMove = "invalid"
+
+// GameRecord is a game as archived by FileArchive.  Outcome is the outcome
+// of the game for our snake (one of the Outcome constants), and Death is
+// how we were eliminated, if we were.  Neither is set in games archived
+// before they were recorded; Result and Eliminated infer them instead.
+type GameRecord struct {
+	Game      v1.Game           `json:"game"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	Decisions []Decision        `json:"states"`
+	Started   time.Time         `json:"startedAt"`
+	Ended     time.Time         `json:"endedAt"`
+	// Won predates Outcome, and is kept only for compatibility with older
+	// archives; use Result instead
+	Won        bool   `json:"won"`
+	Outcome    string `json:"outcome,omitempty"`
+	Death      *Death `json:"death,omitempty"`
 	expiration int64
 }
 
 func NewFileArchive(basePath string, pruneInterval time.Duration, maxAgeBeforePrune time.Duration) GameRecorder {
 	fa := FileArchive{
-		games:             make(map[string]game),
+		games:             make(map[string]GameRecord),
 		basePath:          basePath,
 		pruneInterval:     pruneInterval,
 		maxAgeBeforePrune: maxAgeBeforePrune,
@@ -92,9 +108,10 @@ func gameKey(req v1.GameRequest) string {
 func (r *FileArchive) Start(ctx context.Context, req v1.GameRequest) error {
 	// Start a new game
 	r.mu.Lock()
-	r.games[gameKey(req)] = game{
+	r.games[gameKey(req)] = GameRecord{
 		Game:       req.Game,
-		Decisions:  []decision{},
+		Tags:       Tags(ctx),
+		Decisions:  []Decision{},
 		Started:    time.Now(),
 		expiration: time.Now().Add(r.maxAgeBeforePrune).UnixNano(),
 	}
@@ -118,7 +135,7 @@ func (r *FileArchive) Move(ctx context.Context, req v1.GameRequest, move string)
 		g = r.games[key]
 		r.mu.RUnlock()
 	}
-	g.Decisions = append(g.Decisions, decision{
+	g.Decisions = append(g.Decisions, Decision{
 		BoardState: req.ToBoardState(),
 		Decision:   move,
 	})
@@ -136,14 +153,18 @@ func (r *FileArchive) End(ctx context.Context, req v1.GameRequest) error {
 		return fmt.Errorf("invalid game")
 	}
 	g.Ended = time.Now()
-	g.Decisions = append(g.Decisions, decision{
+	g.Decisions = append(g.Decisions, Decision{
 		BoardState: req.ToBoardState(),
 		Decision:   "end",
 	})
-	g.Won = didWin(g, req)
+	g.Outcome = g.inferOutcome()
+	g.Won = g.Outcome == OutcomeWin
+	if d, died := g.inferDeath(); died {
+		g.Death = &d
+	}
 
 	// Setup cleanup
-	defer r.purge(req.Game.ID)
+	defer r.purge(gameKey(req))
 
 	// Render game to json
 	jsonGame, err := json.MarshalIndent(g, "", "  ")
@@ -157,6 +178,7 @@ func (r *FileArchive) End(ctx context.Context, req v1.GameRequest) error {
 	if err != nil {
 		return err
 	}
+	defer f.Close()
 
 	// Create gzip writer.
 	w := gzip.NewWriter(f)
@@ -167,8 +189,11 @@ func (r *FileArchive) End(ctx context.Context, req v1.GameRequest) error {
 		return err
 	}
 
-	// Close the file.
-	return w.Close()
+	// Flush the compressed stream, then close the file.
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return f.Close()
 }
 
 func (r *FileArchive) Shutdown() error {
@@ -181,17 +206,3 @@ func (r *FileArchive) purge(gameId string) {
 	delete(r.games, gameId)
 	r.mu.Unlock()
 }
-
-func didWin(g game, endState v1.GameRequest) bool {
-	return endState.You.IsValid(endState.Board, endState.Game) && hasNoInvalidDecision(g)
-}
-
-func hasNoInvalidDecision(g game) bool {
-	hasInvalidDecision := false
-	for _, d := range g.Decisions {
-		if d.Decision == "invalid" {
-			hasInvalidDecision = true
-		}
-	}
-	return hasInvalidDecision
-}
@@ -0,0 +1,81 @@
+package gamerecorder
+
+import (
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// Outcomes of an archived game, from our snake's point of view
+const (
+	// OutcomeWin is reported when we (or our squad) were the last left on
+	// the board
+	OutcomeWin = "win"
+	// OutcomeLoss is reported when another snake outlasted us
+	OutcomeLoss = "loss"
+	// OutcomeDraw is reported when the game ended with opponents still on
+	// the board alongside us, or when we were eliminated on the same turn
+	// as the last of them
+	OutcomeDraw = "draw"
+	// OutcomeSolo is reported for games we played alone; there's no one to
+	// beat, so only how long we survived matters
+	OutcomeSolo = "solo"
+)
+
+// Result returns the outcome of the game for our snake.  Games archived
+// before outcomes were recorded have theirs inferred.
+func (g GameRecord) Result() string {
+	if g.Outcome != "" {
+		return g.Outcome
+	}
+	return g.inferOutcome()
+}
+
+// inferOutcome determines the outcome of the game from the final board
+// (recorded from /end) and our last recorded state.
+func (g GameRecord) inferOutcome() string {
+	n := len(g.Decisions)
+	if n == 0 {
+		return OutcomeLoss
+	}
+	if len(g.Decisions[0].BoardState.Board.Snakes) == 1 {
+		return OutcomeSolo
+	}
+	final := g.Decisions[n-1].BoardState
+	you := final.You
+	if n > 1 {
+		you = g.Decisions[n-2].BoardState.You
+	}
+
+	d, died := g.inferDeath()
+	return FinalOutcome(you, final.Board, !died || d.Turn >= final.Turn)
+}
+
+// FinalOutcome determines the outcome of a game for our snake from the
+// final board.  We win if we (or our squad) are the last left on the board,
+// and draw if opponents are left alongside us or if everyone was
+// eliminated on the final turn.  playedFinalTurn reports whether we were
+// still on the board when the final turn began.
+func FinalOutcome(you v1.Battlesnake, final v1.Board, playedFinalTurn bool) string {
+	_, alive := final.Snake(you.ID)
+	teammates, opponents := 0, 0
+	for _, s := range final.Snakes {
+		if s.ID == you.ID {
+			continue
+		}
+		if you.IsTeammate(s) {
+			teammates++
+		} else {
+			opponents++
+		}
+	}
+	switch {
+	case opponents > 0 && alive:
+		return OutcomeDraw
+	case opponents > 0:
+		return OutcomeLoss
+	case alive || teammates > 0:
+		return OutcomeWin
+	case playedFinalTurn:
+		return OutcomeDraw
+	}
+	return OutcomeLoss
+}
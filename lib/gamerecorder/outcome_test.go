@@ -0,0 +1,111 @@
+package gamerecorder
+
+import (
+	"testing"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameRecordOutcome(t *testing.T) {
+	me := v1.Battlesnake{
+		ID:     "me",
+		Health: 50,
+		Head:   v1.Coord{X: 0, Y: 2},
+		Body:   v1.CoordList{{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}},
+	}
+	opponent := v1.Battlesnake{
+		ID:     "opponent",
+		Health: 50,
+		Head:   v1.Coord{X: 4, Y: 0},
+		Body:   v1.CoordList{{X: 4, Y: 0}, {X: 4, Y: 1}, {X: 4, Y: 2}},
+	}
+	starving := me
+	starving.Health = 1
+	starvingOpponent := opponent
+	starvingOpponent.Health = 1
+	squadMe, teammate := me, opponent
+	squadMe.Squad, teammate.Squad = "a", "a"
+	both := v1.Board{Snakes: []v1.Battlesnake{me, opponent}}
+	testCases := []struct {
+		desc     string
+		game     GameRecord
+		expected string
+	}{
+		{
+			desc:     "solo",
+			game:     record(me, v1.Board{Snakes: []v1.Battlesnake{me}}, "up", v1.Board{}, 11),
+			expected: OutcomeSolo,
+		},
+		{
+			desc:     "last snake standing",
+			game:     record(me, both, "up", v1.Board{Snakes: []v1.Battlesnake{me}}, 11),
+			expected: OutcomeWin,
+		},
+		{
+			desc:     "outlasted",
+			game:     record(me, both, "left", v1.Board{Snakes: []v1.Battlesnake{opponent}}, 30),
+			expected: OutcomeLoss,
+		},
+		{
+			desc:     "outlasted by a snake that was later eliminated",
+			game:     record(me, both, "left", v1.Board{}, 30),
+			expected: OutcomeLoss,
+		},
+		{
+			desc:     "still alive together",
+			game:     record(me, both, "up", both, 11),
+			expected: OutcomeDraw,
+		},
+		{
+			desc: "eliminated together",
+			game: record(starving, v1.Board{Snakes: []v1.Battlesnake{starving, starvingOpponent}}, "up",
+				v1.Board{}, 11),
+			expected: OutcomeDraw,
+		},
+		{
+			desc: "outlived by a teammate",
+			game: record(squadMe, v1.Board{Snakes: []v1.Battlesnake{squadMe, teammate}}, "left",
+				v1.Board{Snakes: []v1.Battlesnake{teammate}}, 30),
+			expected: OutcomeWin,
+		},
+		{
+			desc: "recorded outcome",
+			game: func() GameRecord {
+				g := record(me, both, "up", both, 11)
+				g.Outcome = OutcomeLoss
+				return g
+			}(),
+			expected: OutcomeLoss,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, tC.game.Result())
+		})
+	}
+}
+
+func TestFinalOutcome(t *testing.T) {
+	me := v1.Battlesnake{ID: "me", Squad: "red"}
+	teammate := v1.Battlesnake{ID: "teammate", Squad: "red"}
+	opponent := v1.Battlesnake{ID: "opponent", Squad: "blue"}
+	testCases := []struct {
+		desc     string
+		snakes   []v1.Battlesnake
+		played   bool
+		expected string
+	}{
+		{desc: "last snake standing", snakes: []v1.Battlesnake{me}, played: true, expected: OutcomeWin},
+		{desc: "squad outlasted opponents", snakes: []v1.Battlesnake{teammate}, expected: OutcomeWin},
+		{desc: "outlasted", snakes: []v1.Battlesnake{opponent}, expected: OutcomeLoss},
+		{desc: "opponents left alongside us", snakes: []v1.Battlesnake{me, opponent}, played: true, expected: OutcomeDraw},
+		{desc: "eliminated together on the final turn", played: true, expected: OutcomeDraw},
+		{desc: "eliminated before the final turn", expected: OutcomeLoss},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, FinalOutcome(me, v1.Board{Snakes: tC.snakes}, tC.played))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package gamerecorder
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// archiveExt is the extension of the files written by FileArchive
+const archiveExt = ".json.gz"
+
+// Open reads the archived game in the given file.
+func Open(path string) (GameRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return GameRecord{}, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Read reads an archived game (gzipped JSON) from r.
+func Read(r io.Reader) (GameRecord, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return GameRecord{}, err
+	}
+	defer zr.Close()
+	var g GameRecord
+	if err := json.NewDecoder(zr).Decode(&g); err != nil {
+		return GameRecord{}, err
+	}
+	return g, nil
+}
+
+// You returns our snake as it was first recorded in the game.
+func (g GameRecord) You() v1.Battlesnake {
+	if len(g.Decisions) == 0 {
+		return v1.Battlesnake{}
+	}
+	return g.Decisions[0].BoardState.You
+}
+
+// Filter selects archived games.  Zero fields match every game.
+type Filter struct {
+	// Ruleset is the name of the ruleset played
+	Ruleset string
+	// Snake matches either our snake's name or the "snake" tag of the game
+	Snake string
+	// Since and Until bound when the game ended; Since is inclusive and
+	// Until is exclusive
+	Since time.Time
+	Until time.Time
+	// Outcome is one of the Outcome constants
+	Outcome string
+}
+
+// Match determines if the game is selected by the filter.
+func (f Filter) Match(g GameRecord) bool {
+	if f.Ruleset != "" && g.Game.Ruleset.Name != f.Ruleset {
+		return false
+	}
+	if f.Snake != "" && g.You().Name != f.Snake && g.Tags["snake"] != f.Snake {
+		return false
+	}
+	if !f.Since.IsZero() && g.Ended.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !g.Ended.Before(f.Until) {
+		return false
+	}
+	if f.Outcome != "" && g.Result() != f.Outcome {
+		return false
+	}
+	return true
+}
+
+// WalkFunc is called by Walk for each archived game.  When the file could
+// not be read, err describes the problem and the function decides whether
+// to carry on (by returning nil) or stop the walk.
+type WalkFunc func(path string, g GameRecord, err error) error
+
+// Walk reads every archived game under dir, in file name order (which is
+// the order the games ended), calling fn for those selected by the filter.
+func Walk(dir string, f Filter, fn WalkFunc) error {
+	files, err := Files(dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		g, err := Open(path)
+		if err != nil {
+			if err := fn(path, GameRecord{}, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.Match(g) {
+			continue
+		}
+		if err := fn(path, g, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files returns the archive files under dir, sorted by name.
+func Files(dir string) ([]string, error) {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && strings.HasSuffix(path, archiveExt) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return filepath.Base(files[i]) < filepath.Base(files[j])
+	})
+	return files, nil
+}
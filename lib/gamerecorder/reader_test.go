@@ -0,0 +1,137 @@
+package gamerecorder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// archiveGame records a single move game with FileArchive, returning the
+// file it was written to.
+func archiveGame(t *testing.T, ctx context.Context, dir string, id, ruleset, name string) string {
+	fa := NewFileArchive(dir, time.Hour, time.Hour).(*FileArchive)
+	defer fa.Shutdown()
+	you := v1.Battlesnake{
+		ID:     "me",
+		Name:   name,
+		Health: 100,
+		Head:   v1.Coord{X: 1, Y: 1},
+		Body:   v1.CoordList{{X: 1, Y: 1}, {X: 1, Y: 0}},
+	}
+	req := v1.GameRequest{
+		Game:  v1.Game{ID: id, Ruleset: v1.Ruleset{Name: ruleset}},
+		Board: v1.Board{Width: 5, Height: 5, Snakes: []v1.Battlesnake{you}},
+		You:   you,
+	}
+	assert.NoError(t, fa.Start(ctx, req))
+	assert.NoError(t, fa.Move(ctx, req, "up"))
+	req.Turn = 1
+	assert.NoError(t, fa.End(ctx, req))
+	assert.Empty(t, fa.games, "ended games are purged")
+	files, err := filepath.Glob(filepath.Join(dir, "*game="+id+"*"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	return files[0]
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	ctx := WithTag(context.Background(), "variant", "b")
+	path := archiveGame(t, ctx, dir, "g1", v1.RulesetStandard, "snek")
+
+	g, err := Open(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "g1", g.Game.ID)
+	assert.Equal(t, map[string]string{"variant": "b"}, g.Tags)
+	assert.Equal(t, "snek", g.You().Name)
+	if assert.Len(t, g.Decisions, 2) {
+		assert.Equal(t, "up", g.Decisions[0].Decision)
+		assert.Equal(t, "end", g.Decisions[1].Decision)
+		assert.Equal(t, 1, g.Decisions[1].BoardState.Turn)
+	}
+	assert.False(t, g.Ended.IsZero())
+	assert.Equal(t, OutcomeSolo, g.Outcome)
+	assert.Nil(t, g.Death)
+
+	_, err = Open(filepath.Join(dir, "missing.json.gz"))
+	assert.Error(t, err)
+}
+
+func TestWalk(t *testing.T) {
+	dir := t.TempDir()
+	ctx := WithTag(context.Background(), "snake", "beta")
+	archiveGame(t, context.Background(), dir, "g1", v1.RulesetStandard, "snek")
+	archiveGame(t, ctx, dir, "g2", v1.RulesetRoyale, "snek")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "old"), 0755))
+	archiveGame(t, context.Background(), filepath.Join(dir, "old"), "g3", v1.RulesetStandard, "other")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.json.gz"), []byte("nope"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+
+	testCases := []struct {
+		desc     string
+		filter   Filter
+		expected []string
+	}{
+		{
+			desc:     "everything",
+			expected: []string{"g1", "g2", "g3"},
+		},
+		{
+			desc:     "by ruleset",
+			filter:   Filter{Ruleset: v1.RulesetStandard},
+			expected: []string{"g1", "g3"},
+		},
+		{
+			desc:     "by snake name",
+			filter:   Filter{Snake: "other"},
+			expected: []string{"g3"},
+		},
+		{
+			desc:     "by snake tag",
+			filter:   Filter{Snake: "beta"},
+			expected: []string{"g2"},
+		},
+		{
+			desc:     "by outcome",
+			filter:   Filter{Outcome: OutcomeSolo},
+			expected: []string{"g1", "g2", "g3"},
+		},
+		{
+			desc:     "by another outcome",
+			filter:   Filter{Outcome: OutcomeWin},
+			expected: []string{},
+		},
+		{
+			desc:     "ended before",
+			filter:   Filter{Until: time.Now().Add(-time.Hour)},
+			expected: []string{},
+		},
+		{
+			desc:     "ended since",
+			filter:   Filter{Since: time.Now().Add(-time.Hour)},
+			expected: []string{"g1", "g2", "g3"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual := []string{}
+			failed := 0
+			err := Walk(dir, tC.filter, func(path string, g GameRecord, err error) error {
+				if err != nil {
+					failed++
+					return nil
+				}
+				actual = append(actual, g.Game.ID)
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, tC.expected, actual)
+			assert.Equal(t, 1, failed)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	v1 "github.com/clocklear/battlesnake/lib/v1"
 )
@@ -15,7 +16,7 @@ func (r StdOutGameRecorder) Start(ctx context.Context, req v1.GameRequest) error
 	if err != nil {
 		return err
 	}
-	fmt.Printf("START: %v\n\n", string(data))
+	fmt.Printf("START%v: %v\n\n", formatTags(ctx), string(data))
 	return nil
 }
 
@@ -24,7 +25,7 @@ func (r StdOutGameRecorder) Move(ctx context.Context, req v1.GameRequest, move s
 	if err != nil {
 		return err
 	}
-	fmt.Printf("MOVE: %v, responded with '%v'\n", string(data), move)
+	fmt.Printf("MOVE%v: %v, responded with '%v'\n", formatTags(ctx), string(data), move)
 	return nil
 }
 
@@ -33,6 +34,22 @@ func (r StdOutGameRecorder) End(ctx context.Context, req v1.GameRequest) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("END: %v\n", string(data))
+	fmt.Printf("END%v: %v\n", formatTags(ctx), string(data))
 	return nil
 }
+
+// formatTags renders the tags on the context, sorted by key, for prefixing
+// output lines
+func formatTags(ctx context.Context) string {
+	tags := Tags(ctx)
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := ""
+	for _, k := range keys {
+		out += fmt.Sprintf(" [%v=%v]", k, tags[k])
+	}
+	return out
+}
@@ -0,0 +1,24 @@
+package gamerecorder
+
+import "context"
+
+type tagsKey struct{}
+
+// WithTag returns a copy of the context carrying the given tag.  Recorders
+// include the tags found on the context in their output, so recordings
+// can be told apart (for example, by which of our snakes played).
+func WithTag(ctx context.Context, key, value string) context.Context {
+	tags := map[string]string{}
+	for k, v := range Tags(ctx) {
+		tags[k] = v
+	}
+	tags[key] = value
+	return context.WithValue(ctx, tagsKey{}, tags)
+}
+
+// Tags returns the tags carried by the context, if any.  The returned map
+// must not be modified.
+func Tags(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(tagsKey{}).(map[string]string)
+	return tags
+}
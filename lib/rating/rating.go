@@ -0,0 +1,142 @@
+// Package rating estimates the relative strength of players from the
+// results of games between pairs of them, on the Elo scale.
+package rating
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// Base is the rating of an average player
+	Base = 1500
+	// fitIterations is the number of iterations used to fit ratings
+	fitIterations = 500
+)
+
+// Game is the result of a game between two players.  Score is A's share
+// of the points: 1 for a win, 0.5 for a draw and 0 for a loss.
+type Game struct {
+	A     string
+	B     string
+	Score float64
+}
+
+// Interval is a confidence interval around a rating.
+type Interval struct {
+	Low  float64
+	High float64
+}
+
+// Fit estimates ratings from the games using a Bradley-Terry model, the
+// model underlying Elo: the expected score of A against B is
+// 1 / (1 + 10^((Rb - Ra) / 400)).  Draws count as half a win for each
+// player.  So that players who never lose (or never win) get a finite
+// rating, everyone is credited with a win and a loss against an average
+// player.
+func Fit(games []Game) map[string]float64 {
+	players := Players(games)
+	index := map[string]int{}
+	for i, p := range players {
+		index[p] = i
+	}
+	n := len(players)
+	wins := make([]float64, n)
+	counts := make([]map[int]float64, n)
+	for i := range counts {
+		counts[i] = map[int]float64{}
+		// The prior: one win and one loss against an average player
+		wins[i] = 1
+	}
+	for _, g := range games {
+		a, b := index[g.A], index[g.B]
+		wins[a] += g.Score
+		wins[b] += 1 - g.Score
+		counts[a][b]++
+		counts[b][a]++
+	}
+
+	// Minorization-maximization updates (Hunter, 2004), with the average
+	// player fixed at a strength of one
+	strength := make([]float64, n)
+	for i := range strength {
+		strength[i] = 1
+	}
+	for iter := 0; iter < fitIterations; iter++ {
+		next := make([]float64, n)
+		for i := range strength {
+			denominator := 2 / (strength[i] + 1)
+			for j, c := range counts[i] {
+				denominator += c / (strength[i] + strength[j])
+			}
+			next[i] = wins[i] / denominator
+		}
+		strength = next
+	}
+
+	ratings := map[string]float64{}
+	for i, p := range players {
+		ratings[p] = Base + 400*math.Log10(strength[i])
+	}
+	return ratings
+}
+
+// Bootstrap estimates a confidence interval for each player's rating by
+// refitting the ratings to games resampled (with replacement) the given
+// number of times.  The interval covers the given share of the
+// resampled ratings, e.g. 0.95.
+func Bootstrap(games []Game, samples int, confidence float64, r *rand.Rand) map[string]Interval {
+	resampled := map[string][]float64{}
+	sample := make([]Game, len(games))
+	for s := 0; s < samples; s++ {
+		for i := range sample {
+			sample[i] = games[r.Intn(len(games))]
+		}
+		fit := Fit(sample)
+		for _, p := range Players(games) {
+			rating, ok := fit[p]
+			if !ok {
+				// Missing from this sample entirely
+				rating = Base
+			}
+			resampled[p] = append(resampled[p], rating)
+		}
+	}
+
+	intervals := map[string]Interval{}
+	tail := (1 - confidence) / 2
+	for p, ratings := range resampled {
+		sort.Float64s(ratings)
+		intervals[p] = Interval{
+			Low:  percentile(ratings, tail),
+			High: percentile(ratings, 1-tail),
+		}
+	}
+	return intervals
+}
+
+// Players returns the players in the games, sorted by name.
+func Players(games []Game) []string {
+	seen := map[string]bool{}
+	players := []string{}
+	for _, g := range games {
+		for _, p := range []string{g.A, g.B} {
+			if !seen[p] {
+				seen[p] = true
+				players = append(players, p)
+			}
+		}
+	}
+	sort.Strings(players)
+	return players
+}
+
+// percentile returns the value at the given fraction of the sorted values.
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Round(q * float64(len(sorted)-1)))
+	return sorted[i]
+}
@@ -0,0 +1,85 @@
+package rating
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// repeat returns n copies of the game.
+func repeat(g Game, n int) []Game {
+	games := []Game{}
+	for i := 0; i < n; i++ {
+		games = append(games, g)
+	}
+	return games
+}
+
+func TestFit(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		games []Game
+		check func(t *testing.T, ratings map[string]float64)
+	}{
+		{
+			desc:  "even players share the average rating",
+			games: append(repeat(Game{A: "a", B: "b", Score: 1}, 10), repeat(Game{A: "a", B: "b", Score: 0}, 10)...),
+			check: func(t *testing.T, ratings map[string]float64) {
+				assert.InDelta(t, Base, ratings["a"], 0.01)
+				assert.InDelta(t, Base, ratings["b"], 0.01)
+			},
+		},
+		{
+			desc:  "draws are even",
+			games: repeat(Game{A: "a", B: "b", Score: 0.5}, 10),
+			check: func(t *testing.T, ratings map[string]float64) {
+				assert.InDelta(t, ratings["a"], ratings["b"], 0.01)
+			},
+		},
+		{
+			desc:  "a 3:1 record is worth about 190 points",
+			games: append(repeat(Game{A: "a", B: "b", Score: 1}, 300), repeat(Game{A: "a", B: "b", Score: 0}, 100)...),
+			check: func(t *testing.T, ratings map[string]float64) {
+				assert.InDelta(t, 191, ratings["a"]-ratings["b"], 5)
+			},
+		},
+		{
+			desc:  "undefeated players get a finite rating",
+			games: repeat(Game{A: "a", B: "b", Score: 1}, 10),
+			check: func(t *testing.T, ratings map[string]float64) {
+				assert.Greater(t, ratings["a"], ratings["b"])
+				assert.Less(t, ratings["a"], 3000.0)
+			},
+		},
+		{
+			desc: "ratings are transitive",
+			games: append(append(
+				repeat(Game{A: "a", B: "b", Score: 0.75}, 20),
+				repeat(Game{A: "b", B: "c", Score: 0.75}, 20)...),
+				repeat(Game{A: "a", B: "c", Score: 0.9}, 20)...),
+			check: func(t *testing.T, ratings map[string]float64) {
+				assert.Greater(t, ratings["a"], ratings["b"])
+				assert.Greater(t, ratings["b"], ratings["c"])
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			tC.check(t, Fit(tC.games))
+		})
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	games := append(repeat(Game{A: "a", B: "b", Score: 1}, 30), repeat(Game{A: "a", B: "b", Score: 0}, 10)...)
+	ratings := Fit(games)
+	intervals := Bootstrap(games, 200, 0.95, rand.New(rand.NewSource(1)))
+	for _, p := range []string{"a", "b"} {
+		assert.Less(t, intervals[p].Low, ratings[p])
+		assert.Greater(t, intervals[p].High, ratings[p])
+	}
+	// Fewer games make for wider intervals
+	few := Bootstrap(games[25:35], 200, 0.95, rand.New(rand.NewSource(1)))
+	assert.Greater(t, few["a"].High-few["a"].Low, intervals["a"].High-intervals["a"].Low)
+}
@@ -0,0 +1,132 @@
+package strategy
+
+import (
+	"context"
+	"math/rand"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// Names of the built in strategies
+const (
+	Heuristic = v1.AlgorithmHeuristic
+	Minimax   = v1.AlgorithmMinimax
+	MCTS      = v1.AlgorithmMCTS
+	Random    = "random"
+)
+
+func init() {
+	Register(Heuristic, func(opts v1.SolveOptions) Strategy { return heuristic{opts: opts} })
+	Register(Minimax, func(opts v1.SolveOptions) Strategy { return minimax{opts: opts} })
+	Register(MCTS, func(opts v1.SolveOptions) Strategy { return mcts{opts: opts} })
+	Register(Random, func(opts v1.SolveOptions) Strategy { return random{} })
+}
+
+// heuristic scores each of our next moves on its own.
+type heuristic struct {
+	opts v1.SolveOptions
+}
+
+func (st heuristic) Move(ctx context.Context, req v1.GameRequest) (Decision, error) {
+	s := v1.CreateSolver(req)
+	pm, err := s.PossibleMoves(st.opts)
+	if err != nil {
+		return Decision{}, err
+	}
+	scores := map[string]interface{}{}
+	for _, m := range pm {
+		scores[string(m.Direction)] = m.Score
+	}
+	d, err := s.PickMove(pm, st.opts)
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{
+		Move:        d,
+		Diagnostics: map[string]interface{}{"scores": scores},
+	}, nil
+}
+
+// minimax searches the game tree of duels, deepening until the context is
+// done.  Games that aren't duels (or leave no time to search) are left to
+// the heuristic.
+type minimax struct {
+	opts v1.SolveOptions
+}
+
+func (st minimax) Move(ctx context.Context, req v1.GameRequest) (Decision, error) {
+	s := v1.CreateSolver(req)
+	r, err := s.IterativeDeepening(ctx, st.opts.SearchDepth, v1.DefaultEvaluator)
+	if err == v1.ErrNotDuel || outOfTime(ctx, err) {
+		return fallback(ctx, req, st.opts)
+	}
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{
+		Move: r.Move,
+		Diagnostics: map[string]interface{}{
+			"score": r.Score,
+			"depth": r.Depth,
+			"pv":    r.PV,
+		},
+	}, nil
+}
+
+// mcts runs a Monte Carlo tree search over every snake on the board.
+// Searches that run out of time before a single iteration are left to the
+// heuristic.
+type mcts struct {
+	opts v1.SolveOptions
+}
+
+func (st mcts) Move(ctx context.Context, req v1.GameRequest) (Decision, error) {
+	s := v1.CreateSolver(req)
+	r, err := s.MCTS(ctx, v1.MCTSOptions{
+		Iterations: st.opts.MCTSIterations,
+		Budget:     st.opts.MCTSBudget,
+		Seed:       st.opts.MCTSSeed,
+		Rollout:    st.opts.MCTSRollout,
+	})
+	if outOfTime(ctx, err) {
+		return fallback(ctx, req, st.opts)
+	}
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{
+		Move: r.Move,
+		Diagnostics: map[string]interface{}{
+			"score":      r.Score,
+			"iterations": r.Iterations,
+		},
+	}, nil
+}
+
+// fallback decides with the heuristic, which is quick enough to answer
+// when a search can't.
+func fallback(ctx context.Context, req v1.GameRequest, opts v1.SolveOptions) (Decision, error) {
+	d, err := heuristic{opts: opts}.Move(ctx, req)
+	if err != nil {
+		return Decision{}, err
+	}
+	d.Diagnostics["fallback"] = Heuristic
+	return d, nil
+}
+
+// outOfTime determines if a search failed because it ran out of time.
+func outOfTime(ctx context.Context, err error) bool {
+	return err != nil && (err == ctx.Err() || err == context.DeadlineExceeded)
+}
+
+// random picks any move that doesn't immediately run into a wall or our
+// own body.  Useful as a baseline opponent.
+type random struct{}
+
+func (st random) Move(ctx context.Context, req v1.GameRequest) (Decision, error) {
+	pm, err := req.You.PossibleMoves(req.Board, req.Game)
+	if err != nil {
+		return Decision{}, err
+	}
+	return Decision{Move: pm[rand.Intn(len(pm))].Direction}, nil
+}
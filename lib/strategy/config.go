@@ -0,0 +1,56 @@
+package strategy
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// Config names a strategy along with the solve options it runs with, as
+// read from JSON configuration files.
+type Config struct {
+	Name         string       `json:"name"`
+	Strategy     string       `json:"strategy"`
+	SolveOptions SolveOptions `json:"solveOptions"`
+}
+
+// New creates the configured strategy.  If no strategy is named, the
+// algorithm from the solve options is used.
+func (c Config) New() (Strategy, error) {
+	name := c.Strategy
+	if name == "" {
+		name = c.SolveOptions.Algorithm
+	}
+	return New(name, v1.SolveOptions(c.SolveOptions))
+}
+
+// SolveOptions decodes v1.SolveOptions from JSON, accepting durations as
+// strings such as "150ms".  Options missing from the JSON keep the value
+// they had before decoding, so defaults can be filled in first.
+type SolveOptions v1.SolveOptions
+
+func (o *SolveOptions) UnmarshalJSON(data []byte) error {
+	type plain SolveOptions
+	aux := struct {
+		*plain
+		MCTSBudget    string
+		TimeoutMargin string
+	}{plain: (*plain)(o)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var err error
+	if aux.MCTSBudget != "" {
+		if o.MCTSBudget, err = time.ParseDuration(aux.MCTSBudget); err != nil {
+			return fmt.Errorf("MCTSBudget: %w", err)
+		}
+	}
+	if aux.TimeoutMargin != "" {
+		if o.TimeoutMargin, err = time.ParseDuration(aux.TimeoutMargin); err != nil {
+			return fmt.Errorf("TimeoutMargin: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package strategy
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigUnmarshal(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		json          string
+		expected      func(o v1.SolveOptions) v1.SolveOptions
+		expectedError bool
+	}{
+		{
+			desc: "keeps defaults for missing options",
+			json: `{"name":"deep","strategy":"minimax","solveOptions":{"SearchDepth":6}}`,
+			expected: func(o v1.SolveOptions) v1.SolveOptions {
+				o.SearchDepth = 6
+				return o
+			},
+		},
+		{
+			desc: "parses durations",
+			json: `{"name":"mc","solveOptions":{"MCTSBudget":"200ms","TimeoutMargin":"1s"}}`,
+			expected: func(o v1.SolveOptions) v1.SolveOptions {
+				o.MCTSBudget = 200 * time.Millisecond
+				o.TimeoutMargin = time.Second
+				return o
+			},
+		},
+		{
+			desc:          "bad duration",
+			json:          `{"name":"mc","solveOptions":{"MCTSBudget":"soon"}}`,
+			expectedError: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c := Config{SolveOptions: SolveOptions(v1.DefaultSolveOptions)}
+			err := json.Unmarshal([]byte(tC.json), &c)
+			if tC.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected(v1.DefaultSolveOptions), v1.SolveOptions(c.SolveOptions))
+			st, err := c.New()
+			assert.NoError(t, err)
+			assert.NotNil(t, st)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+)
+
+// ErrUnknownStrategy indicates that no strategy is registered with the
+// requested name
+var ErrUnknownStrategy = fmt.Errorf("unknown strategy")
+
+// Decision is the move chosen by a Strategy, along with any diagnostics
+// that explain how it was chosen (scores, search depth, and so on).
+type Decision struct {
+	Move        v1.Direction           `json:"move"`
+	Diagnostics map[string]interface{} `json:"diagnostics,omitempty"`
+}
+
+// Strategy decides which move to make for a game request.  The context
+// is done when the response is due; strategies should return their best
+// move so far rather than run past it.
+type Strategy interface {
+	Move(context.Context, v1.GameRequest) (Decision, error)
+}
+
+// Factory creates a Strategy configured with the given solve options.
+type Factory func(opts v1.SolveOptions) Strategy
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a strategy available under the given name.  It panics if
+// the name is already taken or the factory is nil.
+func Register(name string, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if f == nil {
+		panic("strategy: Register factory is nil")
+	}
+	if _, dup := registry[name]; dup {
+		panic("strategy: Register called twice for " + name)
+	}
+	registry[name] = f
+}
+
+// New creates the strategy registered under the given name.
+func New(name string, opts v1.SolveOptions) (Strategy, error) {
+	registryMu.RLock()
+	f, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownStrategy, name)
+	}
+	return f(opts), nil
+}
+
+// Names returns the names of the registered strategies, sorted.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
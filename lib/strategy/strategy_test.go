@@ -0,0 +1,112 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/clocklear/battlesnake/lib/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		name          string
+		expectedError error
+	}{
+		{desc: "heuristic", name: Heuristic},
+		{desc: "minimax", name: Minimax},
+		{desc: "mcts", name: MCTS},
+		{desc: "random", name: Random},
+		{desc: "unknown", name: "clairvoyant", expectedError: ErrUnknownStrategy},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			st, err := New(tC.name, v1.DefaultSolveOptions)
+			if tC.expectedError != nil {
+				assert.ErrorIs(t, err, tC.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, st)
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, []string{Heuristic, MCTS, Minimax, Random}, Names())
+}
+
+func TestStrategyMove(t *testing.T) {
+	me := v1.Battlesnake{
+		ID:     "me",
+		Health: 100,
+		Head:   v1.Coord{X: 0, Y: 5},
+		Body:   v1.CoordList{{X: 0, Y: 5}, {X: 0, Y: 4}, {X: 0, Y: 3}},
+	}
+	req := v1.GameRequest{
+		Game: v1.Game{ID: "game", Ruleset: v1.Ruleset{Name: v1.RulesetStandard}, Timeout: 500},
+		Board: v1.Board{
+			Height: 11,
+			Width:  11,
+			Snakes: []v1.Battlesnake{
+				me,
+				{
+					ID:     "opponent",
+					Health: 100,
+					Head:   v1.Coord{X: 8, Y: 8},
+					Body:   v1.CoordList{{X: 8, Y: 8}, {X: 8, Y: 9}, {X: 8, Y: 10}},
+				},
+			},
+		},
+		You: me,
+	}
+	opts := v1.DefaultSolveOptions
+	opts.MCTSIterations = 200
+	opts.MCTSSeed = 1
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			st, err := New(name, opts)
+			assert.NoError(t, err)
+			d, err := st.Move(context.Background(), req)
+			assert.NoError(t, err)
+			// The wall is to our left, our body below
+			assert.Contains(t, []v1.Direction{v1.UP, v1.RIGHT}, d.Move)
+		})
+	}
+}
+
+func TestStrategyFallback(t *testing.T) {
+	me := v1.Battlesnake{
+		ID:     "me",
+		Health: 100,
+		Head:   v1.Coord{X: 5, Y: 5},
+		Body:   v1.CoordList{{X: 5, Y: 5}, {X: 5, Y: 4}, {X: 5, Y: 3}},
+	}
+	solo := v1.GameRequest{
+		Game:  v1.Game{ID: "game", Ruleset: v1.Ruleset{Name: v1.RulesetStandard}},
+		Board: v1.Board{Height: 11, Width: 11, Snakes: []v1.Battlesnake{me}},
+		You:   me,
+	}
+	done, cancel := context.WithCancel(context.Background())
+	cancel()
+	testCases := []struct {
+		desc string
+		name string
+		ctx  context.Context
+	}{
+		{desc: "minimax when not a duel", name: Minimax, ctx: context.Background()},
+		{desc: "minimax out of time", name: Minimax, ctx: done},
+		{desc: "mcts out of time", name: MCTS, ctx: done},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			st, err := New(tC.name, v1.DefaultSolveOptions)
+			assert.NoError(t, err)
+			d, err := st.Move(tC.ctx, solo)
+			assert.NoError(t, err)
+			assert.Contains(t, []v1.Direction{v1.UP, v1.LEFT, v1.RIGHT}, d.Move)
+			assert.Equal(t, Heuristic, d.Diagnostics["fallback"])
+		})
+	}
+}
@@ -18,6 +18,8 @@ const (
 )
 
 const (
+	// HazardDamagePerTurn is the default hazard damage, used when the
+	// game settings don't specify one
 	HazardDamagePerTurn = 15
 	MaximumSnakeHealth  = 100
 )
@@ -38,6 +40,23 @@ type Battlesnake struct {
 	Head   Coord     `json:"head"`
 	Length int32     `json:"length"`
 	Shout  string    `json:"shout"`
+	Squad  string    `json:"squad"`
+}
+
+// IsTeammate determines if the given snake is on the same squad as this
+// one.  Snakes are never their own teammate, and snakes without a squad
+// have no teammates.
+func (bs Battlesnake) IsTeammate(other Battlesnake) bool {
+	return bs.Squad != "" && bs.Squad == other.Squad && bs.ID != other.ID
+}
+
+// length returns the length of the snake, falling back to the size of its
+// body when the length wasn't provided.
+func (bs Battlesnake) length() int32 {
+	if bs.Length > 0 {
+		return bs.Length
+	}
+	return int32(len(bs.Body))
 }
 
 // PossibleMoves returns the list of possible coords
@@ -47,24 +66,13 @@ type Battlesnake struct {
 // will the thrown if no moves are possible.
 func (bs Battlesnake) PossibleMoves(b Board, g Game) (CoordList, error) {
 	cl := CoordList{}
-	// for each direction..
-	for _, d := range allDirections {
-		// ..project the head that way
-		c := bs.Head.Project(d)
-
-		// Projection is... different for wrapped games
-		if g.Ruleset.Name == RulesetWrapped {
-			// We need to wrap this coord in a wrapped game
-			c = c.WrapForBoard(b)
-		} else {
-			// Does it fit on the board?
-			if !c.WithinBounds(b) {
-				continue
-			}
-		}
-
-		// Does it overlap with our body?
-		if bs.Body.Contains(c) {
+	obstacles := bs.NextTurnObstacles(g)
+	// for each square next to our head that's on the board
+	// (wrapped games have no edges)..
+	for _, c := range bs.Head.Neighbors(b, g) {
+		// Does it overlap with our body?  Our tail is fair game
+		// if it will have moved out of the way.
+		if obstacles.Contains(c) {
 			continue
 		}
 
@@ -78,22 +86,38 @@ func (bs Battlesnake) PossibleMoves(b Board, g Game) (CoordList, error) {
 	return cl, nil
 }
 
-// Project moves the Battlesnake to the given coordinate on the given board
-func (bs Battlesnake) Project(loc Coord, board Board) Battlesnake {
-	bs.Head = loc
-	// Prepend head to body
-	bs.Body = append([]Coord{loc}, bs.Body...)
-	// Decrement health
-	bs.Health -= 1
+// NextTurnObstacles returns the body segments that will still be occupied
+// once the snake has made its next move.  The tip of the tail moves out of
+// the way unless the snake just ate (leaving a stacked tail) or the game
+// is constrictor, where tails never vacate.
+func (bs Battlesnake) NextTurnObstacles(g Game) CoordList {
+	n := len(bs.Body)
+	if n < 2 || g.Ruleset.Name == RulesetConstrictor || bs.hasStackedTail() {
+		return bs.Body
+	}
+	return bs.Body[:n-1]
+}
+
+// hasStackedTail determines if the last two segments of the snake share a
+// square, which happens when the snake has just eaten.
+func (bs Battlesnake) hasStackedTail() bool {
+	n := len(bs.Body)
+	return n >= 2 && bs.Body[n-1].X == bs.Body[n-2].X && bs.Body[n-1].Y == bs.Body[n-2].Y
+}
+
+// Project moves the Battlesnake to the given coordinate on the given board,
+// following the same rules as Board.Step: the tail moves along, health
+// drains (faster on hazards) and eating food grows the snake with a stacked
+// tail.  Constrictor snakes are fed every turn, so their tail never vacates
+// and their health stays full.
+func (bs Battlesnake) Project(loc Coord, board Board, g Game) Battlesnake {
+	bs.moveTo(loc)
 	willGrow := board.Food.Contains(loc)
-	if willGrow {
-		bs.Health = MaximumSnakeHealth
-	} else {
-		// Drop last elem
-		bs.Body = bs.Body[:len(bs.Body)-1]
+	if board.Hazards.Contains(loc) && !willGrow {
+		bs.Health -= g.HazardDamage()
 	}
-	if board.Hazards.Contains(loc) {
-		bs.Health -= HazardDamagePerTurn
+	if willGrow || g.Ruleset.Name == RulesetConstrictor {
+		bs.feed()
 	}
 	return bs
 }
@@ -101,8 +125,8 @@ func (bs Battlesnake) Project(loc Coord, board Board) Battlesnake {
 // IsValid determines if the snake is 'valid' on the given board.
 // Valid snakes:
 // * have possible moves
-// * have non-zero health
+// * have non-zero health (constrictor snakes never starve)
 func (bs Battlesnake) IsValid(b Board, g Game) bool {
 	_, err := bs.PossibleMoves(b, g)
-	return err == nil && bs.Health > 0
+	return err == nil && (bs.Health > 0 || g.Ruleset.Name == RulesetConstrictor)
 }
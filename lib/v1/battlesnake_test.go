@@ -77,6 +77,10 @@ func TestPossibleMoves(t *testing.T) {
 						X: 5,
 						Y: 6,
 					},
+					{
+						X: 5,
+						Y: 6,
+					},
 				},
 			},
 			b: Board{
@@ -122,6 +126,10 @@ func TestPossibleMoves(t *testing.T) {
 						X: 0,
 						Y: 1,
 					},
+					{
+						X: 0,
+						Y: 1,
+					},
 				},
 			},
 			b: Board{
@@ -165,6 +173,10 @@ func TestPossibleMoves(t *testing.T) {
 						X: 1,
 						Y: 0,
 					},
+					{
+						X: 1,
+						Y: 0,
+					},
 				},
 			},
 			b: Board{
@@ -200,6 +212,10 @@ func TestPossibleMoves(t *testing.T) {
 						X: 10,
 						Y: 9,
 					},
+					{
+						X: 10,
+						Y: 9,
+					},
 				},
 			},
 			b: Board{
@@ -243,6 +259,10 @@ func TestPossibleMoves(t *testing.T) {
 						X: 9,
 						Y: 10,
 					},
+					{
+						X: 9,
+						Y: 10,
+					},
 				},
 			},
 			b: Board{
@@ -286,6 +306,10 @@ func TestPossibleMoves(t *testing.T) {
 						X: 9,
 						Y: 10,
 					},
+					{
+						X: 9,
+						Y: 10,
+					},
 				},
 			},
 			b: Board{
@@ -316,6 +340,86 @@ func TestPossibleMoves(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "success, can chase own tail",
+			s: Battlesnake{
+				Head: Coord{
+					X: 0,
+					Y: 0,
+				},
+				Body: CoordList{
+					{
+						X: 0,
+						Y: 0,
+					},
+					{
+						X: 0,
+						Y: 1,
+					},
+					{
+						X: 1,
+						Y: 1,
+					},
+					{
+						X: 1,
+						Y: 0,
+					},
+				},
+			},
+			b: Board{
+				Height: 11,
+				Width:  11,
+			},
+			g: Game{
+				Ruleset: Ruleset{
+					Name: RulesetStandard,
+				},
+			},
+			expected: CoordList{
+				{
+					X:         1,
+					Y:         0,
+					Direction: RIGHT,
+				},
+			},
+		},
+		{
+			desc: "failure, constrictor tail never moves",
+			s: Battlesnake{
+				Head: Coord{
+					X: 0,
+					Y: 0,
+				},
+				Body: CoordList{
+					{
+						X: 0,
+						Y: 0,
+					},
+					{
+						X: 0,
+						Y: 1,
+					},
+					{
+						X: 1,
+						Y: 1,
+					},
+					{
+						X: 1,
+						Y: 0,
+					},
+				},
+			},
+			b: Board{
+				Height: 11,
+				Width:  11,
+			},
+			g: Game{
+				Ruleset: Ruleset{
+					Name: RulesetConstrictor,
+				},
+			},
+			expectedError: ErrNoPossibleMove,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -329,3 +433,128 @@ func TestPossibleMoves(t *testing.T) {
 		})
 	}
 }
+
+func TestProject(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		s        Battlesnake
+		loc      Coord
+		b        Board
+		g        Game
+		expected Battlesnake
+	}{
+		{
+			desc: "tail follows",
+			s: Battlesnake{
+				Health: 50,
+				Head: Coord{
+					X: 5,
+					Y: 5,
+				},
+				Body: CoordList{
+					{
+						X: 5,
+						Y: 5,
+					},
+					{
+						X: 5,
+						Y: 4,
+					},
+				},
+			},
+			loc: Coord{
+				X: 5,
+				Y: 6,
+			},
+			b: simpleEmptyBoard,
+			g: tstGame,
+			expected: Battlesnake{
+				Health: 49,
+				Length: 2,
+				Head: Coord{
+					X: 5,
+					Y: 6,
+				},
+				Body: CoordList{
+					{
+						X: 5,
+						Y: 6,
+					},
+					{
+						X: 5,
+						Y: 5,
+					},
+				},
+			},
+		},
+		{
+			desc: "eating stacks tail",
+			s: Battlesnake{
+				Health: 50,
+				Head: Coord{
+					X: 5,
+					Y: 5,
+				},
+				Body: CoordList{
+					{
+						X: 5,
+						Y: 5,
+					},
+					{
+						X: 5,
+						Y: 4,
+					},
+				},
+			},
+			loc: Coord{
+				X: 5,
+				Y: 6,
+			},
+			b: Board{
+				Height: 11,
+				Width:  11,
+				Food: CoordList{
+					{
+						X: 5,
+						Y: 6,
+					},
+				},
+				Hazards: CoordList{
+					{
+						X: 5,
+						Y: 6,
+					},
+				},
+			},
+			g: tstGame,
+			expected: Battlesnake{
+				Health: MaximumSnakeHealth,
+				Length: 3,
+				Head: Coord{
+					X: 5,
+					Y: 6,
+				},
+				Body: CoordList{
+					{
+						X: 5,
+						Y: 6,
+					},
+					{
+						X: 5,
+						Y: 5,
+					},
+					{
+						X: 5,
+						Y: 5,
+					},
+				},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual := tC.s.Project(tC.loc, tC.b, tC.g)
+			assert.Equal(t, tC.expected, actual)
+		})
+	}
+}
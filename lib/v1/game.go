@@ -1,5 +1,10 @@
 package v1
 
+import (
+	"context"
+	"time"
+)
+
 type Game struct {
 	ID      string  `json:"id"`
 	Ruleset Ruleset `json:"ruleset"`
@@ -7,8 +12,34 @@ type Game struct {
 }
 
 type Ruleset struct {
-	Name    string `json:"name"`
-	Version string `json:"version"`
+	Name     string          `json:"name"`
+	Version  string          `json:"version"`
+	Settings RulesetSettings `json:"settings"`
+}
+
+// RulesetSettings holds the game settings sent along with the ruleset.
+// HazardDamagePerTurn is nil when the game didn't report it, as zero is a
+// valid setting of its own.
+type RulesetSettings struct {
+	FoodSpawnChance     int            `json:"foodSpawnChance"`
+	MinimumFood         int            `json:"minimumFood"`
+	HazardDamagePerTurn *int32         `json:"hazardDamagePerTurn,omitempty"`
+	Royale              RoyaleSettings `json:"royale"`
+	Squad               SquadSettings  `json:"squad"`
+}
+
+// RoyaleSettings controls how the hazard zone grows in royale games.
+type RoyaleSettings struct {
+	ShrinkEveryNTurns int `json:"shrinkEveryNTurns"`
+}
+
+// SquadSettings controls how snakes on the same squad interact in
+// squad games.
+type SquadSettings struct {
+	AllowBodyCollisions bool `json:"allowBodyCollisions"`
+	SharedElimination   bool `json:"sharedElimination"`
+	SharedHealth        bool `json:"sharedHealth"`
+	SharedLength        bool `json:"sharedLength"`
 }
 
 const (
@@ -19,3 +50,24 @@ const (
 	RulesetConstrictor = "constrictor"
 	RulesetWrapped     = "wrapped"
 )
+
+// HazardDamage returns the health lost by a snake that ends its turn on
+// a hazard.  Games that don't report a value in their settings (such as
+// older archived games) use HazardDamagePerTurn.
+func (g Game) HazardDamage() int32 {
+	if d := g.Ruleset.Settings.HazardDamagePerTurn; d != nil {
+		return *d
+	}
+	return HazardDamagePerTurn
+}
+
+// WithMoveDeadline returns a context that expires when our response to a
+// move request must be on its way: the game's timeout (in milliseconds)
+// from now, less the given margin to allow for network latency.  Games
+// without a timeout produce a context without a deadline.
+func WithMoveDeadline(ctx context.Context, g Game, margin time.Duration) (context.Context, context.CancelFunc) {
+	if g.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Duration(g.Timeout)*time.Millisecond-margin)
+}
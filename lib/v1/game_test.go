@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hazardDamage returns a hazard damage setting for a ruleset.
+func hazardDamage(d int32) *int32 {
+	return &d
+}
+
+func TestGameHazardDamage(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		settings string
+		expected int32
+	}{
+		{desc: "not reported", settings: `{}`, expected: HazardDamagePerTurn},
+		{desc: "custom", settings: `{"hazardDamagePerTurn":30}`, expected: 30},
+		{desc: "no damage", settings: `{"hazardDamagePerTurn":0}`, expected: 0},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			var g Game
+			assert.NoError(t, json.Unmarshal([]byte(`{"ruleset":{"settings":`+tC.settings+`}}`), &g))
+			assert.Equal(t, tC.expected, g.HazardDamage())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package v1
+
+import "math"
+
+// ProjectOnBoard shifts the Coord in the given direction, honoring the
+// edges of the board for the given game.  In wrapped games the result is
+// wrapped around the board; otherwise ok will be false if the result
+// falls off the board.
+func (c Coord) ProjectOnBoard(d Direction, b Board, g Game) (Coord, bool) {
+	p := c.Project(d)
+	if g.Ruleset.Name == RulesetWrapped {
+		return p.WrapForBoard(b), true
+	}
+	return p, p.WithinBounds(b)
+}
+
+// Neighbors returns the squares adjacent to the Coord that are on the
+// board, with the direction required to reach each of them.
+func (c Coord) Neighbors(b Board, g Game) CoordList {
+	cl := CoordList{}
+	for _, d := range allDirections {
+		if n, ok := c.ProjectOnBoard(d, b, g); ok {
+			cl = append(cl, n)
+		}
+	}
+	return cl
+}
+
+// DirectionTo returns the direction that moves this Coord onto the given
+// adjacent Coord, honoring the edges of the board for the given game (see
+// ProjectOnBoard).  ok is false if the Coords aren't adjacent.
+func (c Coord) DirectionTo(other Coord, b Board, g Game) (Direction, bool) {
+	for _, d := range allDirections {
+		if p, _ := c.ProjectOnBoard(d, b, g); p.X == other.X && p.Y == other.Y {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// ManhattanDistanceOnBoard returns the number of moves needed to travel
+// from this Coord to the given Coord on the board, ignoring obstacles.
+// Wrapped games may travel across the edges of the board.
+func (c Coord) ManhattanDistanceOnBoard(other Coord, b Board, g Game) int {
+	dx, dy := c.deltas(other, b, g)
+	return dx + dy
+}
+
+// DistanceOnBoard returns the straight line distance between this Coord
+// and the given Coord.  Wrapped games measure across the edges of the
+// board when that is shorter.
+func (c Coord) DistanceOnBoard(other Coord, b Board, g Game) float64 {
+	dx, dy := c.deltas(other, b, g)
+	return math.Sqrt(math.Pow(float64(dx), 2) + math.Pow(float64(dy), 2))
+}
+
+// deltas returns the absolute distance along each axis between this Coord
+// and the given Coord.
+func (c Coord) deltas(other Coord, b Board, g Game) (int, int) {
+	wrapped := g.Ruleset.Name == RulesetWrapped
+	return axisDelta(c.X, other.X, b.Width, wrapped), axisDelta(c.Y, other.Y, b.Height, wrapped)
+}
+
+// axisDelta returns the distance between two positions on an axis of the
+// given size, optionally allowing travel around the ends of the axis.
+func axisDelta(a, b, size int, wrapped bool) int {
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	if wrapped && size-d < d {
+		d = size - d
+	}
+	return d
+}
+
+// AverageDistanceOnBoard returns the average distance the given coordinate
+// is from the list, as measured by DistanceOnBoard.
+func (cl CoordList) AverageDistanceOnBoard(c Coord, b Board, g Game) float64 {
+	var total float64
+	for _, i := range cl {
+		total += c.DistanceOnBoard(i, b, g)
+	}
+	return total / float64(len(cl))
+}
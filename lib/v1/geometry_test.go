@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var wrappedGame Game = Game{
+	ID: "tst",
+	Ruleset: Ruleset{
+		Name: RulesetWrapped,
+	},
+}
+
+func TestCoordNeighbors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		c        Coord
+		g        Game
+		expected CoordList
+	}{
+		{
+			desc: "standard, corner",
+			c: Coord{
+				X: 0,
+				Y: 0,
+			},
+			g: tstGame,
+			expected: CoordList{
+				{
+					X:         0,
+					Y:         1,
+					Direction: UP,
+				},
+				{
+					X:         1,
+					Y:         0,
+					Direction: RIGHT,
+				},
+			},
+		},
+		{
+			desc: "wrapped, corner",
+			c: Coord{
+				X: 0,
+				Y: 0,
+			},
+			g: wrappedGame,
+			expected: CoordList{
+				{
+					X:         0,
+					Y:         1,
+					Direction: UP,
+				},
+				{
+					X:         0,
+					Y:         10,
+					Direction: DOWN,
+				},
+				{
+					X:         10,
+					Y:         0,
+					Direction: LEFT,
+				},
+				{
+					X:         1,
+					Y:         0,
+					Direction: RIGHT,
+				},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual := tC.c.Neighbors(simpleEmptyBoard, tC.g)
+			assert.ElementsMatch(t, tC.expected, actual)
+		})
+	}
+}
+
+func TestCoordDirectionTo(t *testing.T) {
+	b := Board{Width: 11, Height: 11}
+	testCases := []struct {
+		desc       string
+		a          Coord
+		b          Coord
+		g          Game
+		expected   Direction
+		expectedOk bool
+	}{
+		{desc: "adjacent", a: Coord{X: 5, Y: 5}, b: Coord{X: 5, Y: 6}, g: tstGame, expected: UP, expectedOk: true},
+		{desc: "off the board", a: Coord{X: 0, Y: 5}, b: Coord{X: -1, Y: 5}, g: tstGame, expected: LEFT, expectedOk: true},
+		{desc: "not adjacent", a: Coord{X: 5, Y: 5}, b: Coord{X: 6, Y: 6}, g: tstGame},
+		{desc: "same square", a: Coord{X: 5, Y: 5}, b: Coord{X: 5, Y: 5}, g: tstGame},
+		{desc: "standard, across edge", a: Coord{X: 0, Y: 5}, b: Coord{X: 10, Y: 5}, g: tstGame},
+		{desc: "wrapped, across edge", a: Coord{X: 0, Y: 5}, b: Coord{X: 10, Y: 5}, g: wrappedGame, expected: LEFT, expectedOk: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			d, ok := tC.a.DirectionTo(tC.b, b, tC.g)
+			assert.Equal(t, tC.expected, d)
+			assert.Equal(t, tC.expectedOk, ok)
+		})
+	}
+}
+
+func TestCoordDistanceOnBoard(t *testing.T) {
+	testCases := []struct {
+		desc              string
+		a                 Coord
+		b                 Coord
+		g                 Game
+		expectedManhattan int
+		expectedDistance  float64
+	}{
+		{
+			desc: "standard",
+			a: Coord{
+				X: 0,
+				Y: 0,
+			},
+			b: Coord{
+				X: 10,
+				Y: 0,
+			},
+			g:                 tstGame,
+			expectedManhattan: 10,
+			expectedDistance:  10,
+		},
+		{
+			desc: "wrapped, across edge",
+			a: Coord{
+				X: 0,
+				Y: 0,
+			},
+			b: Coord{
+				X: 10,
+				Y: 0,
+			},
+			g:                 wrappedGame,
+			expectedManhattan: 1,
+			expectedDistance:  1,
+		},
+		{
+			desc: "wrapped, across both edges",
+			a: Coord{
+				X: 1,
+				Y: 9,
+			},
+			b: Coord{
+				X: 9,
+				Y: 1,
+			},
+			g:                 wrappedGame,
+			expectedManhattan: 6,
+			expectedDistance:  4.242640687119285,
+		},
+		{
+			desc: "wrapped, shorter without crossing",
+			a: Coord{
+				X: 4,
+				Y: 5,
+			},
+			b: Coord{
+				X: 6,
+				Y: 5,
+			},
+			g:                 wrappedGame,
+			expectedManhattan: 2,
+			expectedDistance:  2,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expectedManhattan, tC.a.ManhattanDistanceOnBoard(tC.b, simpleEmptyBoard, tC.g))
+			assert.Equal(t, tC.expectedManhattan, tC.b.ManhattanDistanceOnBoard(tC.a, simpleEmptyBoard, tC.g))
+			assert.Equal(t, tC.expectedDistance, tC.a.DistanceOnBoard(tC.b, simpleEmptyBoard, tC.g))
+		})
+	}
+}
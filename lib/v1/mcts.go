@@ -0,0 +1,291 @@
+package v1
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Rollout policies for MCTS
+const (
+	// RolloutRandom plays every snake's possible moves uniformly at random
+	RolloutRandom = "random"
+	// RolloutHeuristic prefers moves that avoid other snakes' bodies and
+	// losing head-to-head collisions
+	RolloutHeuristic = "heuristic"
+)
+
+const (
+	// defaultMCTSIterations is used when no iteration or time budget is given
+	defaultMCTSIterations = 1000
+	// mctsRolloutDepth is the number of turns simulated by each rollout
+	mctsRolloutDepth = 30
+	// mctsExploration is the UCT exploration constant
+	mctsExploration = math.Sqrt2
+)
+
+// MCTSOptions configure a Monte Carlo tree search.
+type MCTSOptions struct {
+	// Iterations limits the number of iterations run; zero is unlimited
+	Iterations int
+	// Budget limits the time spent searching; zero is unlimited
+	Budget time.Duration
+	// Seed seeds the random number generator; zero uses the current time
+	Seed int64
+	// Rollout selects the rollout policy
+	Rollout string
+}
+
+// MCTS searches for our best move using Monte Carlo tree search with
+// decoupled UCT: every snake selects its own move at each node using UCB1
+// over its own statistics, and the joint move is resolved with Board.Step.
+// Rollouts play the game out for a fixed number of turns, after which
+// surviving snakes share the reward.  The search also stops when the
+// context is done; if not a single iteration could be run, the context's
+// error (or context.DeadlineExceeded, when the time budget was too short)
+// is returned.  With a fixed seed and an iteration budget (rather than a
+// time budget or deadline) the search is deterministic.
+func (s Solver) MCTS(ctx context.Context, opts MCTSOptions) (SearchResult, error) {
+	if _, err := s.You.PossibleMoves(s.Board, s.Game); err != nil {
+		return SearchResult{}, err
+	}
+	if _, ok := s.Board.Snake(s.You.ID); !ok {
+		// Make sure we're part of the simulation
+		s.Board = s.Board.Clone()
+		s.Board.Snakes = append(s.Board.Snakes, s.You)
+	}
+	if opts.Iterations <= 0 && opts.Budget <= 0 {
+		opts.Iterations = defaultMCTSIterations
+	}
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	m := mcts{
+		game:    s.Game,
+		rng:     rand.New(rand.NewSource(seed)),
+		rollout: opts.Rollout,
+		players: len(s.Board.Snakes),
+	}
+
+	root := m.newNode(s.Board)
+	var deadline time.Time
+	if opts.Budget > 0 {
+		deadline = time.Now().Add(opts.Budget)
+	}
+	iterations := 0
+	for opts.Iterations <= 0 || iterations < opts.Iterations {
+		if ctx.Err() != nil || (!deadline.IsZero() && time.Now().After(deadline)) {
+			break
+		}
+		m.iterate(root)
+		iterations++
+	}
+	if iterations == 0 {
+		// Any move we picked now would be arbitrary
+		if err := ctx.Err(); err != nil {
+			return SearchResult{}, err
+		}
+		return SearchResult{}, context.DeadlineExceeded
+	}
+
+	// Our most visited move is the most robust choice
+	best := -1
+	for i, stat := range root.stats[s.You.ID] {
+		if best < 0 || stat.visits > root.stats[s.You.ID][best].visits {
+			best = i
+		}
+	}
+	if best < 0 {
+		return SearchResult{}, ErrNoPossibleMove
+	}
+	stat := root.stats[s.You.ID][best]
+	score := 0.0
+	if stat.visits > 0 {
+		score = stat.total / float64(stat.visits)
+	}
+	return SearchResult{
+		Move:       root.actions[s.You.ID][best],
+		Score:      score,
+		Iterations: iterations,
+	}, nil
+}
+
+// mcts holds the state shared across a single search.
+type mcts struct {
+	game    Game
+	rng     *rand.Rand
+	rollout string
+	// players is the number of snakes on the board when the search began
+	players int
+}
+
+// mctsStat accumulates the results of choosing an action at a node.
+type mctsStat struct {
+	visits int
+	total  float64
+}
+
+// mctsNode is a position in the search tree.  Every snake alive at the
+// node has its own set of actions and statistics.
+type mctsNode struct {
+	board    Board
+	visits   int
+	actions  map[string][]Direction
+	stats    map[string][]mctsStat
+	children map[string]*mctsNode
+}
+
+func (m mcts) newNode(b Board) *mctsNode {
+	n := &mctsNode{
+		board:    b,
+		actions:  map[string][]Direction{},
+		stats:    map[string][]mctsStat{},
+		children: map[string]*mctsNode{},
+	}
+	if m.isDecided(b) {
+		return n
+	}
+	for _, s := range b.Snakes {
+		n.actions[s.ID] = candidateDirections(s, b, m.game)
+		n.stats[s.ID] = make([]mctsStat, len(n.actions[s.ID]))
+	}
+	return n
+}
+
+// isDecided determines if the game is over on the given board.  Games that
+// began with a single snake are only over once that snake is gone, since
+// solo games are played to survive.
+func (m mcts) isDecided(b Board) bool {
+	return len(b.Snakes) == 0 || (m.players > 1 && len(b.Snakes) == 1)
+}
+
+// iterate runs a single selection, expansion, rollout and backpropagation
+// pass starting at the given node.
+func (m mcts) iterate(root *mctsNode) {
+	type step struct {
+		node   *mctsNode
+		choice map[string]int
+	}
+	path := []step{}
+	node := root
+	var rewards map[string]float64
+	for {
+		if len(node.actions) == 0 {
+			rewards = m.rewards(node.board)
+			break
+		}
+		choice, moves := m.selectJoint(node)
+		path = append(path, step{node: node, choice: choice})
+		key := jointKey(node.board, moves)
+		child, exists := node.children[key]
+		if !exists {
+			next, _ := node.board.Step(moves, m.game)
+			child = m.newNode(next)
+			node.children[key] = child
+			rewards = m.simulate(next)
+			child.visits++
+			break
+		}
+		node = child
+	}
+
+	// Backpropagate the rewards along the path taken
+	for _, st := range path {
+		st.node.visits++
+		for id, i := range st.choice {
+			st.node.stats[id][i].visits++
+			st.node.stats[id][i].total += rewards[id]
+		}
+	}
+}
+
+// selectJoint chooses an action for every snake at the node using UCB1,
+// trying each untried action (in order) first.
+func (m mcts) selectJoint(n *mctsNode) (map[string]int, map[string]Direction) {
+	choice := map[string]int{}
+	moves := map[string]Direction{}
+	for _, s := range n.board.Snakes {
+		stats := n.stats[s.ID]
+		best, bestValue := 0, math.Inf(-1)
+		for i, stat := range stats {
+			if stat.visits == 0 {
+				best = i
+				break
+			}
+			value := stat.total/float64(stat.visits) + mctsExploration*math.Sqrt(math.Log(float64(n.visits))/float64(stat.visits))
+			if value > bestValue {
+				best, bestValue = i, value
+			}
+		}
+		choice[s.ID] = best
+		moves[s.ID] = n.actions[s.ID][best]
+	}
+	return choice, moves
+}
+
+// jointKey identifies a joint move, listing moves in board order.
+func jointKey(b Board, moves map[string]Direction) string {
+	parts := make([]string, len(b.Snakes))
+	for i, s := range b.Snakes {
+		parts[i] = string(moves[s.ID])
+	}
+	return strings.Join(parts, ",")
+}
+
+// simulate plays out the game from the given board using the rollout
+// policy, returning the reward for every snake on the board.
+func (m mcts) simulate(b Board) map[string]float64 {
+	rewards := map[string]float64{}
+	for _, s := range b.Snakes {
+		rewards[s.ID] = 0
+	}
+	for turn := 0; turn < mctsRolloutDepth; turn++ {
+		if m.isDecided(b) {
+			break
+		}
+		moves := map[string]Direction{}
+		for _, s := range b.Snakes {
+			moves[s.ID] = m.rolloutMove(s, b)
+		}
+		b, _ = b.Step(moves, m.game)
+	}
+	for id, r := range m.rewards(b) {
+		rewards[id] = r
+	}
+	return rewards
+}
+
+// rewards scores the given board: surviving snakes share a reward of one,
+// and eliminated snakes (absent from the board) receive nothing.
+func (m mcts) rewards(b Board) map[string]float64 {
+	rewards := map[string]float64{}
+	for _, s := range b.Snakes {
+		rewards[s.ID] = 1 / float64(len(b.Snakes))
+	}
+	return rewards
+}
+
+// rolloutMove picks a move for the given snake during a rollout.
+func (m mcts) rolloutMove(bs Battlesnake, b Board) Direction {
+	pm, err := bs.PossibleMoves(b, m.game)
+	if err != nil {
+		return bs.Heading(b, m.game)
+	}
+	if m.rollout == RolloutHeuristic {
+		s := Solver{Game: m.game, Board: b, You: bs}
+		lethal, _ := s.headToHeadCells()
+		obstacles := CoordList{}
+		for _, other := range b.Snakes {
+			if other.ID != bs.ID && !(bs.IsTeammate(other) && m.game.squadBodyCollisionsAllowed()) {
+				obstacles = append(obstacles, other.NextTurnObstacles(m.game)...)
+			}
+		}
+		if safe := pm.Eliminate(obstacles).Eliminate(lethal); len(safe) > 0 {
+			pm = safe
+		}
+	}
+	return pm[m.rng.Intn(len(pm))].Direction
+}
@@ -0,0 +1,162 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolverMCTS(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		board         Board
+		you           Battlesnake
+		rollout       string
+		expected      []Direction
+		expectedError error
+	}{
+		{
+			desc: "no possible moves",
+			board: Board{
+				Height: 2,
+				Width:  2,
+				Snakes: []Battlesnake{
+					{
+						ID:     "me",
+						Health: 100,
+						Head:   Coord{X: 0, Y: 0},
+						Body:   CoordList{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 1, Y: 0}},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID:     "me",
+				Health: 100,
+				Head:   Coord{X: 0, Y: 0},
+				Body:   CoordList{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: 0}, {X: 1, Y: 0}},
+			},
+			rollout:       RolloutRandom,
+			expectedError: ErrNoPossibleMove,
+		},
+		{
+			desc: "stays out of the pocket in a three snake game",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "me",
+						Health: 100,
+						Head:   Coord{X: 1, Y: 5},
+						Body:   CoordList{{X: 1, Y: 5}, {X: 2, Y: 5}, {X: 3, Y: 5}, {X: 3, Y: 5}},
+					},
+					{
+						ID:     "wall",
+						Health: 100,
+						Head:   Coord{X: 0, Y: 3},
+						Body:   CoordList{{X: 0, Y: 3}, {X: 1, Y: 3}, {X: 2, Y: 3}, {X: 2, Y: 4}, {X: 2, Y: 4}},
+					},
+					{
+						ID:     "far",
+						Health: 100,
+						Head:   Coord{X: 9, Y: 9},
+						Body:   CoordList{{X: 9, Y: 9}, {X: 9, Y: 8}, {X: 9, Y: 7}},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID:     "me",
+				Health: 100,
+				Head:   Coord{X: 1, Y: 5},
+				Body:   CoordList{{X: 1, Y: 5}, {X: 2, Y: 5}, {X: 3, Y: 5}, {X: 3, Y: 5}},
+			},
+			rollout:  RolloutHeuristic,
+			expected: []Direction{UP, LEFT},
+		},
+		{
+			desc: "avoids a losing head-to-head",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "me",
+						Health: 100,
+						Head:   Coord{X: 5, Y: 5},
+						Body:   CoordList{{X: 5, Y: 5}, {X: 5, Y: 4}, {X: 5, Y: 3}},
+					},
+					{
+						ID:     "big",
+						Health: 100,
+						Head:   Coord{X: 7, Y: 6},
+						Body:   CoordList{{X: 7, Y: 6}, {X: 8, Y: 6}, {X: 9, Y: 6}, {X: 10, Y: 6}, {X: 10, Y: 7}},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID:     "me",
+				Health: 100,
+				Head:   Coord{X: 5, Y: 5},
+				Body:   CoordList{{X: 5, Y: 5}, {X: 5, Y: 4}, {X: 5, Y: 3}},
+			},
+			rollout:  RolloutRandom,
+			expected: []Direction{UP, LEFT},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := Solver{
+				Game:  Game{Ruleset: Ruleset{Name: RulesetStandard}},
+				Board: tC.board,
+				You:   tC.you,
+			}
+			r, err := s.MCTS(context.Background(), MCTSOptions{Iterations: 1000, Seed: 42, Rollout: tC.rollout})
+			assert.Equal(t, tC.expectedError, err)
+			if tC.expectedError == nil {
+				assert.Contains(t, tC.expected, r.Move)
+				assert.Equal(t, 1000, r.Iterations)
+			}
+		})
+	}
+}
+
+func TestSolverMCTSDeterministic(t *testing.T) {
+	s := Solver{
+		Game: Game{Ruleset: Ruleset{Name: RulesetStandard}},
+		Board: Board{
+			Height: 11,
+			Width:  11,
+			Food:   CoordList{{X: 5, Y: 5}, {X: 1, Y: 9}},
+			Snakes: []Battlesnake{
+				{ID: "a", Health: 90, Head: Coord{X: 1, Y: 1}, Body: CoordList{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 3}}},
+				{ID: "b", Health: 90, Head: Coord{X: 9, Y: 9}, Body: CoordList{{X: 9, Y: 9}, {X: 9, Y: 8}, {X: 9, Y: 7}}},
+				{ID: "c", Health: 90, Head: Coord{X: 9, Y: 1}, Body: CoordList{{X: 9, Y: 1}, {X: 8, Y: 1}, {X: 7, Y: 1}}},
+			},
+		},
+		You: Battlesnake{ID: "a", Health: 90, Head: Coord{X: 1, Y: 1}, Body: CoordList{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 3}}},
+	}
+	for _, rollout := range []string{RolloutRandom, RolloutHeuristic} {
+		t.Run(rollout, func(t *testing.T) {
+			opts := MCTSOptions{Iterations: 500, Seed: 7, Rollout: rollout}
+			first, err := s.MCTS(context.Background(), opts)
+			assert.NoError(t, err)
+			second, err := s.MCTS(context.Background(), opts)
+			assert.NoError(t, err)
+			assert.Equal(t, first, second)
+		})
+	}
+}
+
+func TestSolverMCTSOutOfTime(t *testing.T) {
+	you := Battlesnake{ID: "me", Health: 100, Head: Coord{X: 5, Y: 5}, Body: CoordList{{X: 5, Y: 5}, {X: 5, Y: 4}, {X: 5, Y: 3}}}
+	s := Solver{
+		Game:  Game{Ruleset: Ruleset{Name: RulesetStandard}},
+		Board: Board{Height: 11, Width: 11, Snakes: []Battlesnake{you}},
+		You:   you,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := s.MCTS(ctx, MCTSOptions{Iterations: 100})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,278 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// ErrNotDuel indicates that a search requiring exactly two snakes was
+// attempted on a board with some other number of snakes
+var ErrNotDuel = fmt.Errorf("not a duel")
+
+const (
+	// winScore and lossScore bound every evaluation, so decided games
+	// always outrank undecided ones
+	winScore  = 1e6
+	lossScore = -1e6
+	// drawScore is used when both snakes are eliminated on the same turn;
+	// better than losing outright, but not by much
+	drawScore = lossScore / 2
+	// defaultSearchDepth limits iterative deepening when neither a depth
+	// nor a deadline was given
+	defaultSearchDepth = 3
+)
+
+// Evaluator scores a board from the perspective of the snake with the
+// given ID.  Larger scores are better for that snake.
+type Evaluator func(b Board, g Game, you string) float64
+
+// DefaultEvaluator scores a board by the territory the snake controls
+// relative to its opponents, how much longer it is than them, and (to
+// break ties) its health.
+func DefaultEvaluator(b Board, g Game, you string) float64 {
+	me, ok := b.Snake(you)
+	if !ok {
+		return lossScore
+	}
+	t := b.Territory(g)
+	score := float64(t.Areas[you])
+	opponents := 0
+	for _, s := range b.Snakes {
+		if s.ID == you || me.IsTeammate(s) {
+			continue
+		}
+		opponents++
+	}
+	for _, s := range b.Snakes {
+		if s.ID == you || me.IsTeammate(s) {
+			continue
+		}
+		score -= float64(t.Areas[s.ID]) / float64(opponents)
+		score += 2 * float64(me.length()-s.length()) / float64(opponents)
+	}
+	return score + float64(me.Health)/MaximumSnakeHealth
+}
+
+// JointMove is the pair of moves made by both snakes during one turn of a
+// duel.
+type JointMove struct {
+	You      Direction `json:"you"`
+	Opponent Direction `json:"opponent"`
+}
+
+// SearchResult is the outcome of a game tree search.
+type SearchResult struct {
+	// Move is the best move found for our snake
+	Move Direction
+	// Score is the evaluation of the position Move leads to
+	Score float64
+	// Depth is the number of turns searched
+	Depth int
+	// Iterations is the number of iterations run by a Monte Carlo search
+	Iterations int
+	// PV is the principal variation: the sequence of turns the search
+	// expects to be played if both snakes play their best moves
+	PV []JointMove
+}
+
+// Minimax searches the game tree of a duel to the given depth (in turns)
+// using alpha-beta pruning, and returns our best move.  Moves are made
+// simultaneously, so the search is pessimistic: the opponent is assumed to
+// know our move before choosing its own.  Each turn is resolved with
+// Board.Step and positions are scored with the given evaluator.
+func (s Solver) Minimax(depth int, eval Evaluator) (SearchResult, error) {
+	m, err := s.newMinimax(context.Background(), eval)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	if depth < 1 {
+		depth = 1
+	}
+	return m.search(s.Board, depth)
+}
+
+// IterativeDeepening runs Minimax at increasing depths, up to maxDepth,
+// until the context is done.  A maxDepth of zero (or less) deepens until
+// the context is done; without a deadline on the context that would never
+// happen, so defaultSearchDepth is used instead.  The result of the
+// deepest completed search is returned, so a search cut short by the
+// deadline never costs us a move.  Searching stops early once the outcome
+// of the game is certain.  If not even a single turn could be searched,
+// the context's error is returned.
+func (s Solver) IterativeDeepening(ctx context.Context, maxDepth int, eval Evaluator) (SearchResult, error) {
+	m, err := s.newMinimax(ctx, eval)
+	if err != nil {
+		return SearchResult{}, err
+	}
+	if maxDepth < 1 {
+		maxDepth = defaultSearchDepth
+		if _, ok := ctx.Deadline(); ok {
+			maxDepth = math.MaxInt32
+		}
+	}
+	var best SearchResult
+	for depth := 1; depth <= maxDepth; depth++ {
+		r, err := m.search(s.Board, depth)
+		if err == errSearchAborted {
+			break
+		}
+		if err != nil {
+			return SearchResult{}, err
+		}
+		best = r
+		if math.Abs(r.Score) >= winScore {
+			break
+		}
+	}
+	if best.Move == "" {
+		if ctx.Err() != nil {
+			return SearchResult{}, ctx.Err()
+		}
+		return SearchResult{}, ErrNoPossibleMove
+	}
+	return best, nil
+}
+
+// errSearchAborted indicates that a search was stopped by its context
+// before it completed
+var errSearchAborted = fmt.Errorf("search aborted")
+
+// newMinimax prepares a search of the duel on the solver's board.
+func (s Solver) newMinimax(ctx context.Context, eval Evaluator) (minimax, error) {
+	opponent, err := s.duelOpponent()
+	if err != nil {
+		return minimax{}, err
+	}
+	if _, err := s.You.PossibleMoves(s.Board, s.Game); err != nil {
+		return minimax{}, err
+	}
+	return minimax{
+		ctx:      ctx,
+		game:     s.Game,
+		you:      s.You.ID,
+		opponent: opponent.ID,
+		eval:     eval,
+	}, nil
+}
+
+// duelOpponent returns the only other snake on the board.
+func (s Solver) duelOpponent() (Battlesnake, error) {
+	if len(s.Board.Snakes) != 2 {
+		return Battlesnake{}, ErrNotDuel
+	}
+	for _, snake := range s.Board.Snakes {
+		if snake.ID != s.You.ID {
+			return snake, nil
+		}
+	}
+	return Battlesnake{}, ErrNotDuel
+}
+
+// minimax holds the state shared across a single search.
+type minimax struct {
+	ctx      context.Context
+	game     Game
+	you      string
+	opponent string
+	eval     Evaluator
+}
+
+// search runs the search to the given depth from the given board.
+func (m minimax) search(b Board, depth int) (SearchResult, error) {
+	score, pv := m.max(b, depth, math.Inf(-1), math.Inf(1))
+	if m.ctx.Err() != nil {
+		return SearchResult{}, errSearchAborted
+	}
+	if len(pv) == 0 {
+		return SearchResult{}, ErrNoPossibleMove
+	}
+	return SearchResult{
+		Move:  pv[0].You,
+		Score: score,
+		Depth: depth,
+		PV:    pv,
+	}, nil
+}
+
+// max chooses our best move on the given board.  Once the search's
+// context is done every position evaluates to zero so the search unwinds
+// quickly; its result is discarded.
+func (m minimax) max(b Board, depth int, alpha, beta float64) (float64, []JointMove) {
+	if m.ctx.Err() != nil {
+		return 0, nil
+	}
+	if score, done := m.terminal(b, depth); done {
+		return score, nil
+	}
+	me, _ := b.Snake(m.you)
+	best := math.Inf(-1)
+	var bestPV []JointMove
+	for _, d := range candidateDirections(me, b, m.game) {
+		score, pv := m.min(b, d, depth, alpha, beta)
+		if score > best || bestPV == nil {
+			best = score
+			bestPV = pv
+		}
+		if score > alpha {
+			alpha = score
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best, bestPV
+}
+
+// min chooses the opponent's best reply to our move on the given board,
+// then resolves the turn.
+func (m minimax) min(b Board, ours Direction, depth int, alpha, beta float64) (float64, []JointMove) {
+	opponent, _ := b.Snake(m.opponent)
+	best := math.Inf(1)
+	var bestPV []JointMove
+	for _, d := range candidateDirections(opponent, b, m.game) {
+		next, _ := b.Step(map[string]Direction{m.you: ours, m.opponent: d}, m.game)
+		score, pv := m.max(next, depth-1, alpha, beta)
+		if score < best || bestPV == nil {
+			best = score
+			bestPV = append([]JointMove{{You: ours, Opponent: d}}, pv...)
+		}
+		if score < beta {
+			beta = score
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	return best, bestPV
+}
+
+// terminal determines if the search should stop at the given board,
+// returning the score of the position.  Decided games are scored so that
+// quicker wins and slower losses are preferred.
+func (m minimax) terminal(b Board, depth int) (float64, bool) {
+	_, meAlive := b.Snake(m.you)
+	_, opponentAlive := b.Snake(m.opponent)
+	switch {
+	case !meAlive && !opponentAlive:
+		return drawScore, true
+	case !meAlive:
+		return lossScore - float64(depth), true
+	case !opponentAlive:
+		return winScore + float64(depth), true
+	case depth <= 0:
+		return m.eval(b, m.game, m.you), true
+	}
+	return 0, false
+}
+
+// candidateDirections returns the directions worth searching for the given
+// snake: those that don't run into its own body or off the board.  A snake
+// with no such moves still has to move somewhere, so it continues ahead.
+func candidateDirections(bs Battlesnake, b Board, g Game) []Direction {
+	pm, err := bs.PossibleMoves(b, g)
+	if err != nil {
+		return []Direction{bs.Heading(b, g)}
+	}
+	return pm.Directions()
+}
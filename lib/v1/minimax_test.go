@@ -0,0 +1,255 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolverMinimax(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		board         Board
+		you           Battlesnake
+		depth         int
+		expected      []Direction
+		expectWin     bool
+		expectedError error
+	}{
+		{
+			desc: "not a duel",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "me",
+						Health: 100,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID:     "me",
+				Health: 100,
+				Head:   Coord{X: 5, Y: 5},
+				Body: CoordList{
+					{X: 5, Y: 5},
+				},
+			},
+			depth:         2,
+			expectedError: ErrNotDuel,
+		},
+		{
+			desc: "takes a forced head-to-head win",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "me",
+						Health: 100,
+						Head:   Coord{X: 2, Y: 0},
+						Body: CoordList{
+							{X: 2, Y: 0},
+							{X: 3, Y: 0},
+							{X: 4, Y: 0},
+							{X: 5, Y: 0},
+						},
+					},
+					{
+						ID:     "opponent",
+						Health: 100,
+						Head:   Coord{X: 0, Y: 0},
+						Body: CoordList{
+							{X: 0, Y: 0},
+							{X: 0, Y: 1},
+							{X: 0, Y: 2},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID:     "me",
+				Health: 100,
+				Head:   Coord{X: 2, Y: 0},
+				Body: CoordList{
+					{X: 2, Y: 0},
+					{X: 3, Y: 0},
+					{X: 4, Y: 0},
+					{X: 5, Y: 0},
+				},
+			},
+			depth:     1,
+			expected:  []Direction{LEFT},
+			expectWin: true,
+		},
+		{
+			desc: "avoids a losing head-to-head",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "me",
+						Health: 100,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 5, Y: 4},
+							{X: 5, Y: 3},
+						},
+					},
+					{
+						ID:     "opponent",
+						Health: 100,
+						Head:   Coord{X: 7, Y: 5},
+						Body: CoordList{
+							{X: 7, Y: 5},
+							{X: 8, Y: 5},
+							{X: 9, Y: 5},
+							{X: 10, Y: 5},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID:     "me",
+				Health: 100,
+				Head:   Coord{X: 5, Y: 5},
+				Body: CoordList{
+					{X: 5, Y: 5},
+					{X: 5, Y: 4},
+					{X: 5, Y: 3},
+				},
+			},
+			depth:    2,
+			expected: []Direction{UP, LEFT},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := CreateSolver(GameRequest{
+				Game:  tstGame,
+				Board: tC.board,
+				You:   tC.you,
+			})
+			actual, err := s.Minimax(tC.depth, DefaultEvaluator)
+			if tC.expectedError != nil {
+				assert.ErrorIs(t, err, tC.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Contains(t, tC.expected, actual.Move)
+			assert.Equal(t, tC.depth, actual.Depth)
+			assert.NotEmpty(t, actual.PV)
+			assert.Equal(t, actual.Move, actual.PV[0].You)
+			if tC.expectWin {
+				assert.GreaterOrEqual(t, actual.Score, winScore)
+			}
+		})
+	}
+}
+
+func TestSolverIterativeDeepening(t *testing.T) {
+	duel := Board{
+		Height: 11,
+		Width:  11,
+		Snakes: []Battlesnake{
+			{
+				ID:     "me",
+				Health: 100,
+				Head:   Coord{X: 2, Y: 2},
+				Body:   CoordList{{X: 2, Y: 2}, {X: 2, Y: 1}, {X: 2, Y: 0}},
+			},
+			{
+				ID:     "opponent",
+				Health: 100,
+				Head:   Coord{X: 8, Y: 8},
+				Body:   CoordList{{X: 8, Y: 8}, {X: 8, Y: 9}, {X: 8, Y: 10}},
+			},
+		},
+	}
+	you := duel.Snakes[0]
+	testCases := []struct {
+		desc          string
+		timeout       time.Duration
+		maxDepth      int
+		expectedDepth int
+		expectedError error
+	}{
+		{
+			desc:          "searches to the maximum depth without a deadline",
+			maxDepth:      3,
+			expectedDepth: 3,
+		},
+		{
+			desc:          "stops at the deadline with the last completed depth",
+			timeout:       50 * time.Millisecond,
+			maxDepth:      50,
+			expectedDepth: -1,
+		},
+		{
+			desc:          "deepens until the deadline without a depth limit",
+			timeout:       50 * time.Millisecond,
+			maxDepth:      0,
+			expectedDepth: -1,
+		},
+		{
+			desc:          "searches to the default depth without a limit or deadline",
+			maxDepth:      0,
+			expectedDepth: defaultSearchDepth,
+		},
+		{
+			desc:          "no time to search",
+			timeout:       -1,
+			maxDepth:      3,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := CreateSolver(GameRequest{Game: tstGame, Board: duel, You: you})
+			ctx, cancel := context.WithCancel(context.Background())
+			if tC.timeout != 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), tC.timeout)
+			}
+			defer cancel()
+			started := time.Now()
+			actual, err := s.IterativeDeepening(ctx, tC.maxDepth, DefaultEvaluator)
+			if tC.expectedError != nil {
+				assert.ErrorIs(t, err, tC.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, actual.Move)
+			if tC.expectedDepth > 0 {
+				assert.Equal(t, tC.expectedDepth, actual.Depth)
+			} else {
+				assert.GreaterOrEqual(t, actual.Depth, 1)
+				if tC.maxDepth > 0 {
+					assert.Less(t, actual.Depth, tC.maxDepth)
+				}
+				assert.Less(t, int64(time.Since(started)), int64(tC.timeout+50*time.Millisecond))
+			}
+		})
+	}
+}
+
+func TestWithMoveDeadline(t *testing.T) {
+	ctx, cancel := WithMoveDeadline(context.Background(), Game{Timeout: 500}, 100*time.Millisecond)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(400*time.Millisecond), deadline, 20*time.Millisecond)
+
+	ctx, cancel = WithMoveDeadline(context.Background(), Game{}, 100*time.Millisecond)
+	defer cancel()
+	_, ok = ctx.Deadline()
+	assert.False(t, ok)
+}
@@ -0,0 +1,129 @@
+package v1
+
+import "container/heap"
+
+// PathOptions controls how routes across the board are weighed.
+type PathOptions struct {
+	// HazardCost is the extra cost of entering a hazard square
+	HazardCost int
+}
+
+// PathOptionsForGame returns path options that measure routes in health:
+// each move costs one point, and hazards cost the game's hazard damage.
+func PathOptionsForGame(g Game) PathOptions {
+	return PathOptions{
+		HazardCost: int(g.HazardDamage()),
+	}
+}
+
+// Path is a route across the board.
+type Path struct {
+	// Steps are the squares visited after the start, in order, each
+	// carrying the direction taken to reach it
+	Steps CoordList
+	// Cost is the total cost of the route; every step costs one, plus
+	// any hazard cost
+	Cost int
+}
+
+// ShortestPath finds the cheapest path from the start to the nearest of the
+// given targets using A*.  Snake bodies are avoided unless they will have
+// moved out of the way by the time the path reaches them, and wrapped games
+// may route across the edges of the board.  If no target can be reached,
+// ok will be false.
+func (b Board) ShortestPath(start Coord, targets CoordList, g Game, opts PathOptions) (Path, bool) {
+	if len(targets) == 0 || !start.WithinBounds(b) {
+		return Path{}, false
+	}
+	start = Coord{X: start.X, Y: start.Y}
+	if targets.Contains(start) {
+		return Path{Steps: CoordList{}}, true
+	}
+
+	// Estimate the remaining cost as the fewest moves to any target
+	estimate := func(c Coord) int {
+		best := -1
+		for _, t := range targets {
+			if d := c.ManhattanDistanceOnBoard(t, b, g); best < 0 || d < best {
+				best = d
+			}
+		}
+		return best
+	}
+
+	times := b.vacateTimes(g)
+	size := b.Width * b.Height
+	cost := make([]int, size)
+	steps := make([]int, size)
+	from := make([]int, size)
+	via := make([]Coord, size)
+	for i := range cost {
+		cost[i] = -1
+	}
+	cost[b.index(start)] = 0
+	from[b.index(start)] = -1
+
+	open := &pathQueue{}
+	heap.Push(open, pathNode{c: start, priority: estimate(start)})
+	for open.Len() > 0 {
+		node := heap.Pop(open).(pathNode)
+		ci := b.index(node.c)
+		if node.cost > cost[ci] {
+			// Stale entry; a cheaper route was already found
+			continue
+		}
+		if targets.Contains(node.c) {
+			return b.tracePath(ci, cost[ci], from, via), true
+		}
+		for _, n := range node.c.Neighbors(b, g) {
+			ni := b.index(n)
+			if times[ni] > steps[ci]+1 {
+				// Still occupied when we'd arrive
+				continue
+			}
+			nc := cost[ci] + 1
+			if b.Hazards.Contains(n) {
+				nc += opts.HazardCost
+			}
+			if cost[ni] >= 0 && cost[ni] <= nc {
+				continue
+			}
+			cost[ni] = nc
+			steps[ni] = steps[ci] + 1
+			from[ni] = ci
+			via[ni] = n
+			heap.Push(open, pathNode{c: Coord{X: n.X, Y: n.Y}, cost: nc, priority: nc + estimate(n)})
+		}
+	}
+	return Path{}, false
+}
+
+// tracePath walks back from the given square to the start of a search.
+func (b Board) tracePath(i int, cost int, from []int, via []Coord) Path {
+	steps := CoordList{}
+	for ; from[i] >= 0; i = from[i] {
+		steps = append(CoordList{via[i]}, steps...)
+	}
+	return Path{Steps: steps, Cost: cost}
+}
+
+// pathNode is a square waiting to be explored by ShortestPath.
+type pathNode struct {
+	c        Coord
+	cost     int
+	priority int
+}
+
+// pathQueue is a priority queue of squares, cheapest first.
+type pathQueue []pathNode
+
+func (q pathQueue) Len() int            { return len(q) }
+func (q pathQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q pathQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pathQueue) Push(x interface{}) { *q = append(*q, x.(pathNode)) }
+func (q *pathQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
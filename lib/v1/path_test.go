@@ -0,0 +1,171 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardShortestPath(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		board         Board
+		start         Coord
+		targets       CoordList
+		game          Game
+		opts          PathOptions
+		expectedSteps CoordList
+		expectedCost  int
+		expectedOk    bool
+	}{
+		{
+			desc:  "straight line",
+			board: simpleEmptyBoard,
+			start: Coord{X: 5, Y: 5},
+			targets: CoordList{
+				{X: 5, Y: 8},
+			},
+			game: tstGame,
+			expectedSteps: CoordList{
+				{X: 5, Y: 6, Direction: UP},
+				{X: 5, Y: 7, Direction: UP},
+				{X: 5, Y: 8, Direction: UP},
+			},
+			expectedCost: 3,
+			expectedOk:   true,
+		},
+		{
+			desc:  "nearest target",
+			board: simpleEmptyBoard,
+			start: Coord{X: 5, Y: 5},
+			targets: CoordList{
+				{X: 0, Y: 0},
+				{X: 7, Y: 5},
+			},
+			game: tstGame,
+			expectedSteps: CoordList{
+				{X: 6, Y: 5, Direction: RIGHT},
+				{X: 7, Y: 5, Direction: RIGHT},
+			},
+			expectedCost: 2,
+			expectedOk:   true,
+		},
+		{
+			desc:  "already there",
+			board: simpleEmptyBoard,
+			start: Coord{X: 5, Y: 5},
+			targets: CoordList{
+				{X: 5, Y: 5},
+			},
+			game:          tstGame,
+			expectedSteps: CoordList{},
+			expectedCost:  0,
+			expectedOk:    true,
+		},
+		{
+			desc: "detours around hazards",
+			board: Board{
+				Height: 3,
+				Width:  3,
+				Hazards: CoordList{
+					{X: 1, Y: 0},
+				},
+			},
+			start: Coord{X: 0, Y: 0},
+			targets: CoordList{
+				{X: 2, Y: 0},
+			},
+			game: tstGame,
+			opts: PathOptions{
+				HazardCost: 15,
+			},
+			expectedSteps: CoordList{
+				{X: 0, Y: 1, Direction: UP},
+				{X: 1, Y: 1, Direction: RIGHT},
+				{X: 2, Y: 1, Direction: RIGHT},
+				{X: 2, Y: 0, Direction: DOWN},
+			},
+			expectedCost: 4,
+			expectedOk:   true,
+		},
+		{
+			desc:  "wraps around the board",
+			board: simpleEmptyBoard,
+			start: Coord{X: 0, Y: 5},
+			targets: CoordList{
+				{X: 10, Y: 5},
+			},
+			game: wrappedGame,
+			expectedSteps: CoordList{
+				{X: 10, Y: 5, Direction: LEFT},
+			},
+			expectedCost: 1,
+			expectedOk:   true,
+		},
+		{
+			desc: "blocked by a body",
+			board: Board{
+				Height: 3,
+				Width:  3,
+				Snakes: []Battlesnake{
+					{
+						ID:   "a",
+						Head: Coord{X: 1, Y: 2},
+						Body: CoordList{
+							{X: 1, Y: 2},
+							{X: 1, Y: 1},
+							{X: 1, Y: 0},
+							{X: 1, Y: 0},
+						},
+					},
+				},
+			},
+			start: Coord{X: 0, Y: 0},
+			targets: CoordList{
+				{X: 2, Y: 0},
+			},
+			game:       tstGame,
+			expectedOk: false,
+		},
+		{
+			desc: "body moves out of the way",
+			board: Board{
+				Height: 3,
+				Width:  3,
+				Snakes: []Battlesnake{
+					{
+						ID:   "a",
+						Head: Coord{X: 1, Y: 2},
+						Body: CoordList{
+							{X: 1, Y: 2},
+							{X: 1, Y: 1},
+							{X: 1, Y: 0},
+						},
+					},
+				},
+			},
+			start: Coord{X: 0, Y: 0},
+			targets: CoordList{
+				{X: 2, Y: 0},
+			},
+			game: tstGame,
+			expectedSteps: CoordList{
+				{X: 1, Y: 0, Direction: RIGHT},
+				{X: 2, Y: 0, Direction: RIGHT},
+			},
+			expectedCost: 2,
+			expectedOk:   true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual, ok := tC.board.ShortestPath(tC.start, tC.targets, tC.game, tC.opts)
+			assert.Equal(t, tC.expectedOk, ok)
+			if !tC.expectedOk {
+				return
+			}
+			assert.Equal(t, tC.expectedSteps, actual.Steps)
+			assert.Equal(t, tC.expectedCost, actual.Cost)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package v1
+
+import "math/rand"
+
+// royaleForecastHorizon is how many turns ahead the solver looks when
+// penalizing squares that may be engulfed by a shrinking hazard zone.
+const royaleForecastHorizon = 5
+
+// ShrinkForecast describes how the hazard zone of a royale game is
+// expected to grow.  Each shrink covers one edge of the current safe zone,
+// but which edge is chosen at random, so every edge is considered at risk.
+type ShrinkForecast struct {
+	// Interval is the number of turns between shrinks, or zero if unknown
+	Interval int
+	// TurnsUntilShrink is the number of turns until the next shrink
+	TurnsUntilShrink int
+	// Min and Max are the bottom left and top right corners of the
+	// current safe (hazard free) zone
+	Min Coord
+	Max Coord
+	// Valid is false when no safe zone remains
+	Valid bool
+}
+
+// SafeZone returns the bottom left and top right corners of the smallest
+// rectangle containing every hazard free square on the board.  If the
+// entire board is covered by hazards, ok will be false.
+func (b Board) SafeZone() (min Coord, max Coord, ok bool) {
+	min = Coord{X: b.Width, Y: b.Height}
+	max = Coord{X: -1, Y: -1}
+	for x := 0; x < b.Width; x++ {
+		for y := 0; y < b.Height; y++ {
+			c := Coord{X: x, Y: y}
+			if b.Hazards.Contains(c) {
+				continue
+			}
+			if x < min.X {
+				min.X = x
+			}
+			if y < min.Y {
+				min.Y = y
+			}
+			if x > max.X {
+				max.X = x
+			}
+			if y > max.Y {
+				max.Y = y
+			}
+		}
+	}
+	return min, max, max.X >= 0
+}
+
+// ShrinkForecast predicts how the hazard zone will grow for the given game
+// and turn.  The shrink interval comes from the ruleset settings when
+// present; otherwise it is inferred from how far the hazards have already
+// advanced by this turn.
+func (b Board) ShrinkForecast(g Game, turn int) ShrinkForecast {
+	f := ShrinkForecast{}
+	f.Min, f.Max, f.Valid = b.SafeZone()
+	if !f.Valid {
+		return f
+	}
+
+	f.Interval = g.Ruleset.Settings.Royale.ShrinkEveryNTurns
+	if f.Interval <= 0 {
+		// Every shrink takes one row or column from the board
+		shrinks := (b.Width - (f.Max.X - f.Min.X + 1)) + (b.Height - (f.Max.Y - f.Min.Y + 1))
+		if shrinks == 0 || turn <= 0 {
+			// Nothing to go on yet
+			return f
+		}
+		f.Interval = turn / shrinks
+		if f.Interval == 0 {
+			return f
+		}
+	}
+	f.TurnsUntilShrink = f.Interval - (turn % f.Interval)
+	return f
+}
+
+// TurnsUntilEngulfed returns the earliest number of turns before the given
+// square could be covered by hazard.  Squares already outside the safe zone
+// return zero.  If the shrink interval is unknown, ok will be false.
+func (f ShrinkForecast) TurnsUntilEngulfed(c Coord) (turns int, ok bool) {
+	if !f.Valid || c.X < f.Min.X || c.X > f.Max.X || c.Y < f.Min.Y || c.Y > f.Max.Y {
+		return 0, true
+	}
+	if f.Interval <= 0 {
+		return 0, false
+	}
+	// Distance to the nearest edge of the safe zone determines how many
+	// shrinks must happen before this square is covered
+	depth := c.X - f.Min.X
+	for _, d := range []int{f.Max.X - c.X, c.Y - f.Min.Y, f.Max.Y - c.Y} {
+		if d < depth {
+			depth = d
+		}
+	}
+	return f.TurnsUntilShrink + depth*f.Interval, true
+}
+
+// PredictedHazards returns the squares of the safe zone that could be
+// covered by hazard within the given number of turns.
+func (f ShrinkForecast) PredictedHazards(turnsAhead int) CoordList {
+	cl := CoordList{}
+	if !f.Valid || f.Interval <= 0 {
+		return cl
+	}
+	for x := f.Min.X; x <= f.Max.X; x++ {
+		for y := f.Min.Y; y <= f.Max.Y; y++ {
+			c := Coord{X: x, Y: y}
+			if turns, _ := f.TurnsUntilEngulfed(c); turns <= turnsAhead {
+				cl = append(cl, c)
+			}
+		}
+	}
+	return cl
+}
+
+// ShrinkHazards returns a copy of the board with the hazard zone grown for
+// the given turn of a royale game.  Every ShrinkEveryNTurns turns, one edge
+// of the safe zone (chosen at random) is covered by hazard.  Other games,
+// and turns between shrinks, leave the hazards as they are.
+func (b Board) ShrinkHazards(g Game, turn int, r *rand.Rand) Board {
+	next := b.Clone()
+	interval := g.Ruleset.Settings.Royale.ShrinkEveryNTurns
+	if g.Ruleset.Name != RulesetRoyale || interval <= 0 || turn <= 0 || turn%interval != 0 {
+		return next
+	}
+	min, max, ok := next.SafeZone()
+	if !ok {
+		return next
+	}
+	edge := CoordList{}
+	switch r.Intn(4) {
+	case 0:
+		for y := min.Y; y <= max.Y; y++ {
+			edge = append(edge, Coord{X: min.X, Y: y})
+		}
+	case 1:
+		for y := min.Y; y <= max.Y; y++ {
+			edge = append(edge, Coord{X: max.X, Y: y})
+		}
+	case 2:
+		for x := min.X; x <= max.X; x++ {
+			edge = append(edge, Coord{X: x, Y: min.Y})
+		}
+	default:
+		for x := min.X; x <= max.X; x++ {
+			edge = append(edge, Coord{X: x, Y: max.Y})
+		}
+	}
+	for _, c := range edge {
+		if !next.Hazards.Contains(c) {
+			next.Hazards = append(next.Hazards, c)
+		}
+	}
+	return next
+}
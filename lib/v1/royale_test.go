@@ -0,0 +1,222 @@
+package v1
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hazardColumns returns hazards covering the given columns of a board
+func hazardColumns(b Board, columns ...int) CoordList {
+	cl := CoordList{}
+	for _, x := range columns {
+		for y := 0; y < b.Height; y++ {
+			cl = append(cl, Coord{X: x, Y: y})
+		}
+	}
+	return cl
+}
+
+func TestBoardShrinkForecast(t *testing.T) {
+	board := Board{
+		Height: 7,
+		Width:  7,
+	}
+	testCases := []struct {
+		desc     string
+		hazards  CoordList
+		game     Game
+		turn     int
+		expected ShrinkForecast
+	}{
+		{
+			desc: "no hazards, unknown interval",
+			game: Game{Ruleset: Ruleset{Name: RulesetRoyale}},
+			turn: 10,
+			expected: ShrinkForecast{
+				Min:   Coord{X: 0, Y: 0},
+				Max:   Coord{X: 6, Y: 6},
+				Valid: true,
+			},
+		},
+		{
+			desc: "interval from settings",
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetRoyale,
+					Settings: RulesetSettings{
+						Royale: RoyaleSettings{ShrinkEveryNTurns: 25},
+					},
+				},
+			},
+			turn: 10,
+			expected: ShrinkForecast{
+				Interval:         25,
+				TurnsUntilShrink: 15,
+				Min:              Coord{X: 0, Y: 0},
+				Max:              Coord{X: 6, Y: 6},
+				Valid:            true,
+			},
+		},
+		{
+			desc:    "interval inferred from hazards",
+			hazards: hazardColumns(board, 0, 6),
+			game:    Game{Ruleset: Ruleset{Name: RulesetRoyale}},
+			turn:    45,
+			expected: ShrinkForecast{
+				Interval:         22,
+				TurnsUntilShrink: 21,
+				Min:              Coord{X: 1, Y: 0},
+				Max:              Coord{X: 5, Y: 6},
+				Valid:            true,
+			},
+		},
+		{
+			desc:     "board covered by hazards",
+			hazards:  hazardColumns(board, 0, 1, 2, 3, 4, 5, 6),
+			game:     Game{Ruleset: Ruleset{Name: RulesetRoyale}},
+			turn:     100,
+			expected: ShrinkForecast{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			b := board
+			b.Hazards = tC.hazards
+			actual := b.ShrinkForecast(tC.game, tC.turn)
+			assert.Equal(t, tC.expected.Interval, actual.Interval)
+			assert.Equal(t, tC.expected.TurnsUntilShrink, actual.TurnsUntilShrink)
+			assert.Equal(t, tC.expected.Valid, actual.Valid)
+			if tC.expected.Valid {
+				assert.Equal(t, tC.expected.Min, actual.Min)
+				assert.Equal(t, tC.expected.Max, actual.Max)
+			}
+		})
+	}
+}
+
+func TestShrinkForecastTurnsUntilEngulfed(t *testing.T) {
+	f := ShrinkForecast{
+		Interval:         10,
+		TurnsUntilShrink: 3,
+		Min:              Coord{X: 1, Y: 0},
+		Max:              Coord{X: 5, Y: 6},
+		Valid:            true,
+	}
+	testCases := []struct {
+		desc     string
+		c        Coord
+		expected int
+	}{
+		{
+			desc:     "already hazard",
+			c:        Coord{X: 0, Y: 3},
+			expected: 0,
+		},
+		{
+			desc:     "edge of safe zone",
+			c:        Coord{X: 1, Y: 3},
+			expected: 3,
+		},
+		{
+			desc:     "one square in",
+			c:        Coord{X: 2, Y: 3},
+			expected: 13,
+		},
+		{
+			desc:     "center",
+			c:        Coord{X: 3, Y: 3},
+			expected: 23,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual, ok := f.TurnsUntilEngulfed(tC.c)
+			assert.True(t, ok)
+			assert.Equal(t, tC.expected, actual)
+		})
+	}
+
+	// The squares around the edge of the safe zone are at risk of the next shrink
+	assert.Len(t, f.PredictedHazards(5), 5*7-3*5)
+	assert.Len(t, f.PredictedHazards(2), 0)
+}
+
+func TestSolverPathBoard(t *testing.T) {
+	royale := Game{Ruleset: Ruleset{
+		Name:     RulesetRoyale,
+		Settings: RulesetSettings{Royale: RoyaleSettings{ShrinkEveryNTurns: 10}},
+	}}
+	testCases := []struct {
+		desc     string
+		game     Game
+		expected int
+	}{
+		{desc: "standard", game: tstGame, expected: 3},
+		// The food sits on the edge of the safe zone, two turns before it shrinks
+		{desc: "royale", game: royale, expected: 3 + HazardDamagePerTurn},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			s := Solver{Game: tC.game, Turn: 8, Board: Board{Width: 7, Height: 7, Food: CoordList{{X: 0, Y: 3}}}}
+			b := s.pathBoard(s.Board, s.Board.ShrinkForecast(s.Game, s.Turn))
+			path, ok := b.ShortestPath(Coord{X: 3, Y: 3}, s.Board.Food, s.Game, PathOptionsForGame(s.Game))
+			assert.True(t, ok)
+			assert.Equal(t, tC.expected, path.Cost)
+			assert.Empty(t, s.Board.Hazards) // the solver's board is untouched
+		})
+	}
+}
+
+func TestBoardShrinkHazards(t *testing.T) {
+	royale := Game{Ruleset: Ruleset{
+		Name:     RulesetRoyale,
+		Settings: RulesetSettings{Royale: RoyaleSettings{ShrinkEveryNTurns: 5}},
+	}}
+	board := Board{Height: 7, Width: 7}
+	testCases := []struct {
+		desc     string
+		game     Game
+		board    Board
+		turn     int
+		expected int
+	}{
+		{
+			desc:     "shrinks on the interval",
+			game:     royale,
+			board:    board,
+			turn:     5,
+			expected: 7,
+		},
+		{
+			desc:     "shrinks the remaining safe zone",
+			game:     royale,
+			board:    Board{Height: 7, Width: 7, Hazards: hazardColumns(board, 0, 6)},
+			turn:     10,
+			expected: 21,
+		},
+		{
+			desc:     "waits between shrinks",
+			game:     royale,
+			board:    board,
+			turn:     4,
+			expected: 0,
+		},
+		{
+			desc:     "only shrinks royale games",
+			game:     Game{Ruleset: Ruleset{Name: RulesetStandard, Settings: royale.Ruleset.Settings}},
+			board:    board,
+			turn:     5,
+			expected: 0,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual := tC.board.ShrinkHazards(tC.game, tC.turn, rand.New(rand.NewSource(1)))
+			assert.Len(t, actual.Hazards, tC.expected)
+			_, _, ok := actual.SafeZone()
+			assert.True(t, ok)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+package v1
+
+import "math/rand"
+
+// EliminationCause describes why a Battlesnake was removed from the board.
+// Values match the causes reported by the official rules engine.
+type EliminationCause string
+
+const (
+	EliminatedByCollision     EliminationCause = "snake-collision"
+	EliminatedBySelfCollision EliminationCause = "snake-self-collision"
+	EliminatedByOutOfHealth   EliminationCause = "out-of-health"
+	EliminatedByHeadToHead    EliminationCause = "head-collision"
+	EliminatedByOutOfBounds   EliminationCause = "wall-collision"
+	EliminatedBySquad         EliminationCause = "squad-eliminated"
+)
+
+// Elimination records a Battlesnake that was removed from the board
+// during a turn, why it was removed, and (for collisions) the ID of the
+// snake it ran into.
+type Elimination struct {
+	ID    string           `json:"id"`
+	Cause EliminationCause `json:"cause"`
+	By    string           `json:"by,omitempty"`
+}
+
+// Clone returns a deep copy of the board so that it can be modified
+// without affecting the original.
+func (b Board) Clone() Board {
+	nb := Board{
+		Height: b.Height,
+		Width:  b.Width,
+	}
+	if b.Food != nil {
+		nb.Food = append(CoordList{}, b.Food...)
+	}
+	if b.Hazards != nil {
+		nb.Hazards = append(CoordList{}, b.Hazards...)
+	}
+	nb.Snakes = make([]Battlesnake, len(b.Snakes))
+	for i, s := range b.Snakes {
+		s.Body = append(CoordList{}, s.Body...)
+		nb.Snakes[i] = s
+	}
+	return nb
+}
+
+// Snake returns the Battlesnake with the given ID, if it is on the board.
+func (b Board) Snake(id string) (Battlesnake, bool) {
+	for _, s := range b.Snakes {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return Battlesnake{}, false
+}
+
+// Step advances the board by one turn, applying the given moves for every
+// snake simultaneously.  Snakes without an entry in moves continue in the
+// direction they are currently heading.  The returned board contains only
+// the snakes that survived the turn; the eliminated snakes are reported
+// separately along with the cause.
+//
+// Turn resolution follows the official rules:
+// * every snake moves and loses one point of health
+// * snakes whose head ends on a hazard take hazard damage (unless they eat)
+// * snakes whose head ends on food eat it, restoring health and growing
+// * in constrictor games every snake grows and is restored to full health
+// * snakes are eliminated for starvation, walls, and body collisions
+// * the shorter snake in a head-to-head collision is eliminated (ties lose both)
+// * in squad games, squad settings are applied (see applySquadRules)
+func (b Board) Step(moves map[string]Direction, g Game) (Board, []Elimination) {
+	next := b.Clone()
+
+	// Move every snake
+	for i := range next.Snakes {
+		s := &next.Snakes[i]
+		d, ok := moves[s.ID]
+		if !ok {
+			d = s.Heading(next, g)
+		}
+		s.move(d, next, g)
+	}
+
+	// Reduce health, applying hazard damage where appropriate
+	for i := range next.Snakes {
+		s := &next.Snakes[i]
+		if next.Hazards.Contains(s.Head) && !next.Food.Contains(s.Head) {
+			s.Health -= g.HazardDamage()
+		}
+	}
+
+	// Feed snakes.  Constrictor snakes are fed every turn, so they always
+	// grow and never lose health.
+	eaten := CoordList{}
+	for i := range next.Snakes {
+		s := &next.Snakes[i]
+		onFood := next.Food.Contains(s.Head)
+		if onFood || g.Ruleset.Name == RulesetConstrictor {
+			s.feed()
+		}
+		if onFood {
+			eaten = append(eaten, s.Head)
+		}
+	}
+	next.Food = next.Food.Eliminate(eaten)
+
+	// Determine eliminations.  Starvation and leaving the board are
+	// resolved first; collisions are then checked only against the
+	// snakes that remain.
+	eliminations := []Elimination{}
+	eliminated := map[string]bool{}
+	for _, s := range next.Snakes {
+		if s.Health <= 0 {
+			eliminations = append(eliminations, Elimination{ID: s.ID, Cause: EliminatedByOutOfHealth})
+			eliminated[s.ID] = true
+		} else if !s.Head.WithinBounds(next) {
+			eliminations = append(eliminations, Elimination{ID: s.ID, Cause: EliminatedByOutOfBounds})
+			eliminated[s.ID] = true
+		}
+	}
+	collisions := []Elimination{}
+	for _, s := range next.Snakes {
+		if eliminated[s.ID] {
+			continue
+		}
+		if e, collided := s.collision(next.Snakes, eliminated, g); collided {
+			collisions = append(collisions, e)
+		}
+	}
+	for _, e := range collisions {
+		eliminations = append(eliminations, e)
+		eliminated[e.ID] = true
+	}
+
+	// Squad members share their fate (and possibly their health and length)
+	if g.Ruleset.Name == RulesetSquad {
+		eliminations = append(eliminations, next.applySquadRules(g, eliminated)...)
+	}
+
+	// Remove the eliminated snakes from the board
+	survivors := []Battlesnake{}
+	for _, s := range next.Snakes {
+		if !eliminated[s.ID] {
+			survivors = append(survivors, s)
+		}
+	}
+	next.Snakes = survivors
+
+	return next, eliminations
+}
+
+// Elimination steps the board with the given moves (see Step), returning
+// the elimination of the snake with the given ID if it was eliminated that
+// turn.
+func (b Board) Elimination(id string, moves map[string]Direction, g Game) (Elimination, bool) {
+	_, eliminations := b.Step(moves, g)
+	for _, e := range eliminations {
+		if e.ID == id {
+			return e, true
+		}
+	}
+	return Elimination{}, false
+}
+
+// Heading returns the direction the snake is currently travelling, based
+// on the position of its head relative to its neck.  Snakes that have not
+// yet moved (or are stacked on a single square) are considered to be
+// heading UP.
+func (bs Battlesnake) Heading(b Board, g Game) Direction {
+	if len(bs.Body) < 2 {
+		return UP
+	}
+	if d, ok := bs.Body[1].DirectionTo(bs.Body[0], b, g); ok {
+		return d
+	}
+	return UP
+}
+
+// move advances the snake one square in the given direction.  Snakes may
+// leave the board here; they'll be eliminated for it.
+func (bs *Battlesnake) move(d Direction, b Board, g Game) {
+	if len(bs.Body) == 0 {
+		return
+	}
+	h, _ := bs.Body[0].ProjectOnBoard(d, b, g)
+	bs.moveTo(h)
+}
+
+// moveTo places the snake's head on the given square, dropping the last
+// segment of its tail and draining one point of health.
+func (bs *Battlesnake) moveTo(h Coord) {
+	h.Direction = ""
+	bs.Head = h
+	bs.Body = append(CoordList{h}, bs.Body[:len(bs.Body)-1]...)
+	bs.Length = int32(len(bs.Body))
+	bs.Health -= 1
+}
+
+// feed restores the snake to full health and grows it by stacking a
+// duplicate segment on its tail.
+func (bs *Battlesnake) feed() {
+	bs.Health = MaximumSnakeHealth
+	bs.Body = append(bs.Body, bs.Body[len(bs.Body)-1])
+	bs.Length = int32(len(bs.Body))
+}
+
+// collision determines if the snake's head collides with any snake not
+// already eliminated, returning the resulting elimination.  Squad games
+// may allow snakes to pass through the bodies of their teammates.
+func (bs Battlesnake) collision(snakes []Battlesnake, eliminated map[string]bool, g Game) (Elimination, bool) {
+	if len(bs.Body) == 0 {
+		return Elimination{}, false
+	}
+	// Self collision
+	if bs.Body[1:].Contains(bs.Head) {
+		return Elimination{ID: bs.ID, Cause: EliminatedBySelfCollision, By: bs.ID}, true
+	}
+	// Body collision
+	for _, other := range snakes {
+		if other.ID == bs.ID || eliminated[other.ID] || len(other.Body) == 0 {
+			continue
+		}
+		if g.squadBodyCollisionsAllowed() && bs.IsTeammate(other) {
+			continue
+		}
+		if other.Body[1:].Contains(bs.Head) {
+			return Elimination{ID: bs.ID, Cause: EliminatedByCollision, By: other.ID}, true
+		}
+	}
+	// Head-to-head collision; the shorter snake loses, ties lose together
+	for _, other := range snakes {
+		if other.ID == bs.ID || eliminated[other.ID] {
+			continue
+		}
+		if other.Head.X == bs.Head.X && other.Head.Y == bs.Head.Y && len(bs.Body) <= len(other.Body) {
+			return Elimination{ID: bs.ID, Cause: EliminatedByHeadToHead, By: other.ID}, true
+		}
+	}
+	return Elimination{}, false
+}
+
+// SpawnFood returns a copy of the board with food spawned according to the
+// game settings.  Food is added until the board holds MinimumFood pieces;
+// beyond that a single piece is added FoodSpawnChance percent of the time.
+// Food is only placed on unoccupied squares, and never in constrictor games.
+func (b Board) SpawnFood(g Game, r *rand.Rand) Board {
+	next := b.Clone()
+	if g.Ruleset.Name == RulesetConstrictor {
+		return next
+	}
+	settings := g.Ruleset.Settings
+	count := 0
+	if len(next.Food) < settings.MinimumFood {
+		count = settings.MinimumFood - len(next.Food)
+	} else if settings.FoodSpawnChance > 0 && r.Intn(100) < settings.FoodSpawnChance {
+		count = 1
+	}
+	if count == 0 {
+		return next
+	}
+
+	// Find unoccupied squares
+	free := CoordList{}
+	for x := 0; x < next.Width; x++ {
+		for y := 0; y < next.Height; y++ {
+			c := Coord{X: x, Y: y}
+			if next.Food.Contains(c) || next.occupied(c) {
+				continue
+			}
+			free = append(free, c)
+		}
+	}
+	for i := 0; i < count && len(free) > 0; i++ {
+		idx := r.Intn(len(free))
+		next.Food = append(next.Food, free[idx])
+		free = append(free[:idx], free[idx+1:]...)
+	}
+	return next
+}
+
+// occupied determines if any snake's body covers the given square
+func (b Board) occupied(c Coord) bool {
+	for _, s := range b.Snakes {
+		if s.Body.Contains(c) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,939 @@
+package v1
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardStep(t *testing.T) {
+	testCases := []struct {
+		desc                 string
+		board                Board
+		moves                map[string]Direction
+		game                 Game
+		expectedSnakes       []Battlesnake
+		expectedFood         CoordList
+		expectedEliminations []Elimination
+	}{
+		{
+			desc: "simple move",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 90,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 5, Y: 4},
+							{X: 5, Y: 3},
+						},
+						Length: 3,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": UP,
+			},
+			game: Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Health: 89,
+					Head:   Coord{X: 5, Y: 6},
+					Body: CoordList{
+						{X: 5, Y: 6},
+						{X: 5, Y: 5},
+						{X: 5, Y: 4},
+					},
+					Length: 3,
+				},
+			},
+			expectedFood:         CoordList{},
+			expectedEliminations: []Elimination{},
+		},
+		{
+			desc: "missing move continues heading",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 90,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 4, Y: 5},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{},
+			game:  Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Health: 89,
+					Head:   Coord{X: 6, Y: 5},
+					Body: CoordList{
+						{X: 6, Y: 5},
+						{X: 5, Y: 5},
+					},
+					Length: 2,
+				},
+			},
+			expectedFood:         CoordList{},
+			expectedEliminations: []Elimination{},
+		},
+		{
+			desc: "eating food grows and restores health",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Food: CoordList{
+					{X: 5, Y: 6},
+					{X: 1, Y: 1},
+				},
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 10,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 5, Y: 4},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": UP,
+			},
+			game: Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Health: MaximumSnakeHealth,
+					Head:   Coord{X: 5, Y: 6},
+					Body: CoordList{
+						{X: 5, Y: 6},
+						{X: 5, Y: 5},
+						{X: 5, Y: 5},
+					},
+					Length: 3,
+				},
+			},
+			expectedFood: CoordList{
+				{X: 1, Y: 1},
+			},
+			expectedEliminations: []Elimination{},
+		},
+		{
+			desc: "hazard damage",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Hazards: CoordList{
+					{X: 5, Y: 6},
+				},
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 50,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 5, Y: 4},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": UP,
+			},
+			game: Game{Ruleset: Ruleset{Name: RulesetRoyale}},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Health: 50 - 1 - HazardDamagePerTurn,
+					Head:   Coord{X: 5, Y: 6},
+					Body: CoordList{
+						{X: 5, Y: 6},
+						{X: 5, Y: 5},
+					},
+					Length: 2,
+				},
+			},
+			expectedFood:         CoordList{},
+			expectedEliminations: []Elimination{},
+		},
+		{
+			desc: "constrictor snakes always grow and stay healthy",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 100,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 5, Y: 4},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": UP,
+			},
+			game: Game{Ruleset: Ruleset{Name: RulesetConstrictor}},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Health: MaximumSnakeHealth,
+					Head:   Coord{X: 5, Y: 6},
+					Body: CoordList{
+						{X: 5, Y: 6},
+						{X: 5, Y: 5},
+						{X: 5, Y: 5},
+					},
+					Length: 3,
+				},
+			},
+			expectedFood:         CoordList{},
+			expectedEliminations: []Elimination{},
+		},
+		{
+			desc: "custom hazard damage",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Hazards: CoordList{
+					{X: 5, Y: 6},
+				},
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 50,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 5, Y: 4},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": UP,
+			},
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetStandard,
+					Settings: RulesetSettings{
+						HazardDamagePerTurn: hazardDamage(49),
+					},
+				},
+			},
+			expectedSnakes: []Battlesnake{},
+			expectedFood:   CoordList{},
+			expectedEliminations: []Elimination{
+				{ID: "a", Cause: EliminatedByOutOfHealth},
+			},
+		},
+		{
+			desc: "starvation",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 1,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 5, Y: 4},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": UP,
+			},
+			game:           Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{},
+			expectedFood:   CoordList{},
+			expectedEliminations: []Elimination{
+				{ID: "a", Cause: EliminatedByOutOfHealth},
+			},
+		},
+		{
+			desc: "wall collision",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 90,
+						Head:   Coord{X: 0, Y: 5},
+						Body: CoordList{
+							{X: 0, Y: 5},
+							{X: 1, Y: 5},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": LEFT,
+			},
+			game:           Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{},
+			expectedFood:   CoordList{},
+			expectedEliminations: []Elimination{
+				{ID: "a", Cause: EliminatedByOutOfBounds},
+			},
+		},
+		{
+			desc: "wrapped games do not have walls",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 90,
+						Head:   Coord{X: 0, Y: 5},
+						Body: CoordList{
+							{X: 0, Y: 5},
+							{X: 1, Y: 5},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": LEFT,
+			},
+			game: Game{Ruleset: Ruleset{Name: RulesetWrapped}},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Health: 89,
+					Head:   Coord{X: 10, Y: 5},
+					Body: CoordList{
+						{X: 10, Y: 5},
+						{X: 0, Y: 5},
+					},
+					Length: 2,
+				},
+			},
+			expectedFood:         CoordList{},
+			expectedEliminations: []Elimination{},
+		},
+		{
+			desc: "self collision",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 90,
+						Head:   Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+							{X: 5, Y: 4},
+							{X: 6, Y: 4},
+							{X: 6, Y: 5},
+							{X: 6, Y: 6},
+						},
+						Length: 5,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": RIGHT,
+			},
+			game:           Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{},
+			expectedFood:   CoordList{},
+			expectedEliminations: []Elimination{
+				{ID: "a", Cause: EliminatedBySelfCollision, By: "a"},
+			},
+		},
+		{
+			desc: "body collision",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 90,
+						Head:   Coord{X: 5, Y: 6},
+						Body: CoordList{
+							{X: 5, Y: 6},
+							{X: 4, Y: 6},
+						},
+						Length: 2,
+					},
+					{
+						ID:     "b",
+						Health: 90,
+						Head:   Coord{X: 6, Y: 7},
+						Body: CoordList{
+							{X: 6, Y: 7},
+							{X: 6, Y: 6},
+							{X: 6, Y: 5},
+						},
+						Length: 3,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": RIGHT,
+				"b": UP,
+			},
+			game: Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "b",
+					Health: 89,
+					Head:   Coord{X: 6, Y: 8},
+					Body: CoordList{
+						{X: 6, Y: 8},
+						{X: 6, Y: 7},
+						{X: 6, Y: 6},
+					},
+					Length: 3,
+				},
+			},
+			expectedFood: CoordList{},
+			expectedEliminations: []Elimination{
+				{ID: "a", Cause: EliminatedByCollision, By: "b"},
+			},
+		},
+		{
+			desc: "head-to-head, shorter snake loses",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 90,
+						Head:   Coord{X: 4, Y: 5},
+						Body: CoordList{
+							{X: 4, Y: 5},
+							{X: 3, Y: 5},
+						},
+						Length: 2,
+					},
+					{
+						ID:     "b",
+						Health: 90,
+						Head:   Coord{X: 6, Y: 5},
+						Body: CoordList{
+							{X: 6, Y: 5},
+							{X: 7, Y: 5},
+							{X: 8, Y: 5},
+						},
+						Length: 3,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": RIGHT,
+				"b": LEFT,
+			},
+			game: Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "b",
+					Health: 89,
+					Head:   Coord{X: 5, Y: 5},
+					Body: CoordList{
+						{X: 5, Y: 5},
+						{X: 6, Y: 5},
+						{X: 7, Y: 5},
+					},
+					Length: 3,
+				},
+			},
+			expectedFood: CoordList{},
+			expectedEliminations: []Elimination{
+				{ID: "a", Cause: EliminatedByHeadToHead, By: "b"},
+			},
+		},
+		{
+			desc: "head-to-head, equal length eliminates both",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Health: 90,
+						Head:   Coord{X: 4, Y: 5},
+						Body: CoordList{
+							{X: 4, Y: 5},
+							{X: 3, Y: 5},
+						},
+						Length: 2,
+					},
+					{
+						ID:     "b",
+						Health: 90,
+						Head:   Coord{X: 6, Y: 5},
+						Body: CoordList{
+							{X: 6, Y: 5},
+							{X: 7, Y: 5},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": RIGHT,
+				"b": LEFT,
+			},
+			game:           Game{Ruleset: Ruleset{Name: RulesetStandard}},
+			expectedSnakes: []Battlesnake{},
+			expectedFood:   CoordList{},
+			expectedEliminations: []Elimination{
+				{ID: "a", Cause: EliminatedByHeadToHead, By: "b"},
+				{ID: "b", Cause: EliminatedByHeadToHead, By: "a"},
+			},
+		},
+		{
+			desc: "squad, body collisions with teammates allowed",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Squad:  "red",
+						Health: 90,
+						Head:   Coord{X: 5, Y: 6},
+						Body: CoordList{
+							{X: 5, Y: 6},
+							{X: 4, Y: 6},
+						},
+						Length: 2,
+					},
+					{
+						ID:     "b",
+						Squad:  "red",
+						Health: 50,
+						Head:   Coord{X: 6, Y: 7},
+						Body: CoordList{
+							{X: 6, Y: 7},
+							{X: 6, Y: 6},
+							{X: 6, Y: 5},
+						},
+						Length: 3,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": RIGHT,
+				"b": UP,
+			},
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetSquad,
+					Settings: RulesetSettings{
+						Squad: SquadSettings{
+							AllowBodyCollisions: true,
+							SharedHealth:        true,
+							SharedLength:        true,
+						},
+					},
+				},
+			},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Squad:  "red",
+					Health: 89,
+					Head:   Coord{X: 6, Y: 6},
+					Body: CoordList{
+						{X: 6, Y: 6},
+						{X: 5, Y: 6},
+						{X: 5, Y: 6},
+					},
+					Length: 3,
+				},
+				{
+					ID:     "b",
+					Squad:  "red",
+					Health: 89,
+					Head:   Coord{X: 6, Y: 8},
+					Body: CoordList{
+						{X: 6, Y: 8},
+						{X: 6, Y: 7},
+						{X: 6, Y: 6},
+					},
+					Length: 3,
+				},
+			},
+			expectedFood:         CoordList{},
+			expectedEliminations: []Elimination{},
+		},
+		{
+			desc: "squad, shared elimination",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Squad:  "red",
+						Health: 90,
+						Head:   Coord{X: 0, Y: 5},
+						Body: CoordList{
+							{X: 0, Y: 5},
+							{X: 1, Y: 5},
+						},
+						Length: 2,
+					},
+					{
+						ID:     "b",
+						Squad:  "red",
+						Health: 90,
+						Head:   Coord{X: 6, Y: 7},
+						Body: CoordList{
+							{X: 6, Y: 7},
+							{X: 6, Y: 6},
+						},
+						Length: 2,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": LEFT,
+				"b": UP,
+			},
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetSquad,
+					Settings: RulesetSettings{
+						Squad: SquadSettings{
+							SharedElimination: true,
+						},
+					},
+				},
+			},
+			expectedSnakes: []Battlesnake{},
+			expectedFood:   CoordList{},
+			expectedEliminations: []Elimination{
+				{ID: "a", Cause: EliminatedByOutOfBounds},
+				{ID: "b", Cause: EliminatedBySquad, By: "a"},
+			},
+		},
+		{
+			desc: "squad, shared health",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Food:   CoordList{{X: 2, Y: 3}},
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Squad:  "red",
+						Health: 40,
+						Head:   Coord{X: 2, Y: 2},
+						Body:   CoordList{{X: 2, Y: 2}, {X: 2, Y: 1}, {X: 2, Y: 0}},
+						Length: 3,
+					},
+					{
+						ID:     "b",
+						Squad:  "red",
+						Health: 60,
+						Head:   Coord{X: 8, Y: 8},
+						Body:   CoordList{{X: 8, Y: 8}, {X: 8, Y: 7}, {X: 8, Y: 6}},
+						Length: 3,
+					},
+					{
+						ID:     "c",
+						Squad:  "blue",
+						Health: 30,
+						Head:   Coord{X: 5, Y: 5},
+						Body:   CoordList{{X: 5, Y: 5}, {X: 5, Y: 4}, {X: 5, Y: 3}},
+						Length: 3,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": UP,
+				"b": UP,
+				"c": UP,
+			},
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetSquad,
+					Settings: RulesetSettings{
+						Squad: SquadSettings{
+							SharedHealth: true,
+						},
+					},
+				},
+			},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Squad:  "red",
+					Health: 100,
+					Head:   Coord{X: 2, Y: 3},
+					Body:   CoordList{{X: 2, Y: 3}, {X: 2, Y: 2}, {X: 2, Y: 1}, {X: 2, Y: 1}},
+					Length: 4,
+				},
+				{
+					ID:     "b",
+					Squad:  "red",
+					Health: 100,
+					Head:   Coord{X: 8, Y: 9},
+					Body:   CoordList{{X: 8, Y: 9}, {X: 8, Y: 8}, {X: 8, Y: 7}},
+					Length: 3,
+				},
+				{
+					ID:     "c",
+					Squad:  "blue",
+					Health: 29,
+					Head:   Coord{X: 5, Y: 6},
+					Body:   CoordList{{X: 5, Y: 6}, {X: 5, Y: 5}, {X: 5, Y: 4}},
+					Length: 3,
+				},
+			},
+			expectedFood:         CoordList{},
+			expectedEliminations: []Elimination{},
+		},
+		{
+			desc: "squad, shared length",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:     "a",
+						Squad:  "red",
+						Health: 80,
+						Head:   Coord{X: 2, Y: 4},
+						Body:   CoordList{{X: 2, Y: 4}, {X: 2, Y: 3}, {X: 2, Y: 2}, {X: 2, Y: 1}, {X: 2, Y: 0}},
+						Length: 5,
+					},
+					{
+						ID:     "b",
+						Squad:  "red",
+						Health: 60,
+						Head:   Coord{X: 8, Y: 8},
+						Body:   CoordList{{X: 8, Y: 8}, {X: 8, Y: 7}, {X: 8, Y: 6}},
+						Length: 3,
+					},
+					{
+						ID:     "c",
+						Squad:  "blue",
+						Health: 30,
+						Head:   Coord{X: 5, Y: 5},
+						Body:   CoordList{{X: 5, Y: 5}, {X: 5, Y: 4}, {X: 5, Y: 3}},
+						Length: 3,
+					},
+				},
+			},
+			moves: map[string]Direction{
+				"a": UP,
+				"b": UP,
+				"c": UP,
+			},
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetSquad,
+					Settings: RulesetSettings{
+						Squad: SquadSettings{
+							SharedLength: true,
+						},
+					},
+				},
+			},
+			expectedSnakes: []Battlesnake{
+				{
+					ID:     "a",
+					Squad:  "red",
+					Health: 79,
+					Head:   Coord{X: 2, Y: 5},
+					Body:   CoordList{{X: 2, Y: 5}, {X: 2, Y: 4}, {X: 2, Y: 3}, {X: 2, Y: 2}, {X: 2, Y: 1}},
+					Length: 5,
+				},
+				{
+					ID:     "b",
+					Squad:  "red",
+					Health: 59,
+					Head:   Coord{X: 8, Y: 9},
+					Body:   CoordList{{X: 8, Y: 9}, {X: 8, Y: 8}, {X: 8, Y: 7}, {X: 8, Y: 7}, {X: 8, Y: 7}},
+					Length: 5,
+				},
+				{
+					ID:     "c",
+					Squad:  "blue",
+					Health: 29,
+					Head:   Coord{X: 5, Y: 6},
+					Body:   CoordList{{X: 5, Y: 6}, {X: 5, Y: 5}, {X: 5, Y: 4}},
+					Length: 3,
+				},
+			},
+			expectedFood:         CoordList{},
+			expectedEliminations: []Elimination{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			original := tC.board.Clone()
+			next, eliminations := tC.board.Step(tC.moves, tC.game)
+			assert.Equal(t, tC.expectedSnakes, next.Snakes)
+			assert.Equal(t, tC.expectedFood, next.Food)
+			assert.Equal(t, tC.expectedEliminations, eliminations)
+			assert.Equal(t, original, tC.board) // the original board is untouched
+		})
+	}
+}
+
+func TestBoardElimination(t *testing.T) {
+	b := Board{
+		Height: 11,
+		Width:  11,
+		Snakes: []Battlesnake{
+			{ID: "a", Health: 100, Head: Coord{X: 0, Y: 5}, Body: CoordList{{X: 0, Y: 5}, {X: 1, Y: 5}, {X: 2, Y: 5}}},
+			{ID: "b", Health: 100, Head: Coord{X: 5, Y: 5}, Body: CoordList{{X: 5, Y: 5}, {X: 5, Y: 4}, {X: 5, Y: 3}}},
+		},
+	}
+	testCases := []struct {
+		desc       string
+		id         string
+		moves      map[string]Direction
+		expected   Elimination
+		expectedOk bool
+	}{
+		{desc: "eliminated", id: "a", moves: map[string]Direction{"a": LEFT}, expected: Elimination{ID: "a", Cause: EliminatedByOutOfBounds}, expectedOk: true},
+		{desc: "survives", id: "a", moves: map[string]Direction{"a": UP}},
+		{desc: "another snake eliminated", id: "b", moves: map[string]Direction{"a": LEFT}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			e, ok := b.Elimination(tC.id, tC.moves, tstGame)
+			assert.Equal(t, tC.expected, e)
+			assert.Equal(t, tC.expectedOk, ok)
+		})
+	}
+}
+
+func TestBoardSpawnFood(t *testing.T) {
+	board := Board{
+		Height: 3,
+		Width:  3,
+		Food: CoordList{
+			{X: 0, Y: 0},
+		},
+		Snakes: []Battlesnake{
+			{
+				ID:   "a",
+				Head: Coord{X: 1, Y: 1},
+				Body: CoordList{
+					{X: 1, Y: 1},
+					{X: 1, Y: 2},
+				},
+			},
+		},
+	}
+	testCases := []struct {
+		desc          string
+		game          Game
+		expectedCount int
+	}{
+		{
+			desc: "spawns up to minimum food",
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetStandard,
+					Settings: RulesetSettings{
+						MinimumFood: 3,
+					},
+				},
+			},
+			expectedCount: 3,
+		},
+		{
+			desc: "always spawns with full chance",
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetStandard,
+					Settings: RulesetSettings{
+						MinimumFood:     1,
+						FoodSpawnChance: 100,
+					},
+				},
+			},
+			expectedCount: 2,
+		},
+		{
+			desc: "never spawns without chance",
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetStandard,
+					Settings: RulesetSettings{
+						MinimumFood: 1,
+					},
+				},
+			},
+			expectedCount: 1,
+		},
+		{
+			desc: "limited by free squares",
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetStandard,
+					Settings: RulesetSettings{
+						MinimumFood: 20,
+					},
+				},
+			},
+			expectedCount: 7,
+		},
+		{
+			desc: "constrictor never spawns",
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetConstrictor,
+					Settings: RulesetSettings{
+						MinimumFood: 3,
+					},
+				},
+			},
+			expectedCount: 1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			next := board.SpawnFood(tC.game, rand.New(rand.NewSource(1)))
+			assert.Len(t, next.Food, tC.expectedCount)
+			for _, f := range next.Food {
+				assert.False(t, board.Snakes[0].Body.Contains(f))
+			}
+			assert.Len(t, board.Food, 1) // the original board is untouched
+		})
+	}
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/go-kit/kit/log"
 )
@@ -30,12 +31,39 @@ func (s *Solver) WithLogger(l log.Logger) *Solver {
 	return s
 }
 
+// Algorithms that can be selected with SolveOptions; each is available as
+// a strategy of the same name (see package strategy)
+const (
+	// AlgorithmHeuristic scores each of our next moves on its own
+	AlgorithmHeuristic = "heuristic"
+	// AlgorithmMinimax searches the game tree of duels, falling back to
+	// the heuristic when there are more (or fewer) than two snakes
+	AlgorithmMinimax = "minimax"
+	// AlgorithmMCTS runs a Monte Carlo tree search over every snake on the
+	// board, within the iteration and time budget given
+	AlgorithmMCTS = "mcts"
+)
+
 type SolveOptions struct {
 	Lookahead                bool
 	ConsiderOpponentNextMove bool
 	UseSingleBestOption      bool
 	FoodReward               int
 	HazardPenalty            int
+	TeammatePenalty          int
+	ShrinkPenalty            int
+	HeadToHeadReward         int
+	HeadToHeadPenalty        int
+	SpacePenalty             int
+	FoodSeekReward           int
+	TerritoryWeight          int
+	Algorithm                string
+	SearchDepth              int
+	MCTSIterations           int
+	MCTSBudget               time.Duration
+	MCTSSeed                 int64
+	MCTSRollout              string
+	TimeoutMargin            time.Duration
 }
 
 var DefaultSolveOptions SolveOptions = SolveOptions{
@@ -44,6 +72,20 @@ var DefaultSolveOptions SolveOptions = SolveOptions{
 	UseSingleBestOption:      false,
 	FoodReward:               20,
 	HazardPenalty:            40,
+	TeammatePenalty:          10,
+	ShrinkPenalty:            20,
+	HeadToHeadReward:         5,
+	HeadToHeadPenalty:        50,
+	SpacePenalty:             60,
+	FoodSeekReward:           30,
+	TerritoryWeight:          20,
+	Algorithm:                AlgorithmHeuristic,
+	SearchDepth:              0,
+	MCTSIterations:           0,
+	MCTSBudget:               150 * time.Millisecond,
+	MCTSSeed:                 0,
+	MCTSRollout:              RolloutHeuristic,
+	TimeoutMargin:            100 * time.Millisecond,
 }
 
 // PossibleMoves returns a list of possible moves that could be taken next
@@ -66,31 +108,38 @@ func (s Solver) PossibleMoves(opts SolveOptions) (CoordList, error) {
 			continue
 		}
 
-		// Gather position of this snakes body pieces
-		otherSnakesPositions = append(otherSnakesPositions, snake.Body...)
-
-		if opts.ConsiderOpponentNextMove {
-			// Determine possible moves of this snake
-			pm, err := snake.PossibleMoves(s.Board, s.Game)
-			if err != nil {
-				// snakes next moves is not a threat -- has no valid moves
-				continue
-			}
-			otherSnakesPositions = append(otherSnakesPositions, pm...)
+		// Gather position of this snakes body pieces that will still be
+		// there next turn, unless this is a teammate we're allowed to
+		// pass through
+		teammate := s.You.IsTeammate(snake)
+		if !teammate || !s.Game.squadBodyCollisionsAllowed() {
+			otherSnakesPositions = append(otherSnakesPositions, snake.NextTurnObstacles(s.Game)...)
 		}
+
 	}
 
 	// Determine if any valid (safe) moves exist
 	myPossibleMoves = myPossibleMoves.Eliminate(otherSnakesPositions)
 
+	if opts.ConsiderOpponentNextMove {
+		// Avoid squares where we'd lose (or tie) a head-to-head collision.
+		// These are only lethal if the opponent actually moves there, so
+		// if nothing else remains keep them; scoring will penalize them.
+		lethal, _ := s.headToHeadCells()
+		if safe := myPossibleMoves.Eliminate(lethal); len(safe) > 0 {
+			myPossibleMoves = safe
+		}
+	}
+
 	if opts.Lookahead {
 		// For each possible move, project our snake into that position
 		// and see if moves exist.  If no move exists, drop that option.
 		// This is naive because it doesn't take the moves of other snake
-		// into consideration, entirely.
+		// into consideration, entirely.  Projection honors the ruleset, so
+		// constrictor snakes keep their (permanent) tail.
 		safeMoves := CoordList{}
 		for _, pv := range myPossibleMoves {
-			nS := s.You.Project(pv, s.Board)
+			nS := s.You.Project(pv, s.Board, s.Game)
 			if nS.IsValid(s.Board, s.Game) {
 				// Should be safe
 				safeMoves = append(safeMoves, pv)
@@ -114,10 +163,14 @@ func (s Solver) score(moves CoordList, opts SolveOptions) CoordList {
 	// Given the list of possible moves, 'score' each one, sort the list
 	// based on score, and return
 	scored := CoordList{}
+	teammateMoves := s.teammateMoves()
+	forecast := s.Board.ShrinkForecast(s.Game, s.Turn)
+	lethal, winning := s.headToHeadCells()
 	for _, m := range moves {
 		// Adjust scores by avoiding self
-		// Find avg distance to first 8 body points
-		avgDistance := s.You.Body.First(8).AverageDistance(m)
+		// Find avg distance to first 8 body points (across the edges
+		// of the board in wrapped games)
+		avgDistance := s.You.Body.First(8).AverageDistanceOnBoard(m, s.Board, s.Game)
 		m.Score += avgDistance
 
 		// Amend score by considering food
@@ -131,13 +184,66 @@ func (s Solver) score(moves CoordList, opts SolveOptions) CoordList {
 			m.Score += float64(opts.FoodReward)
 		}
 
-		// Consider board hazards
+		// Consider board hazards, scaling the penalty for games that deal
+		// more (or less) damage than usual
 		if s.Board.Hazards != nil && len(s.Board.Hazards) > 0 {
 			if s.Board.Hazards.Contains(m) {
-				m.Score -= float64(opts.HazardPenalty)
+				m.Score -= float64(opts.HazardPenalty) * float64(s.Game.HazardDamage()) / HazardDamagePerTurn
+			}
+		}
+
+		// In royale games, avoid squares the hazard zone is about to engulf.
+		// The sooner the square could be covered, the larger the penalty.
+		if s.Game.Ruleset.Name == RulesetRoyale && !s.Board.Hazards.Contains(m) {
+			if turns, ok := forecast.TurnsUntilEngulfed(m); ok && turns <= royaleForecastHorizon {
+				m.Score -= float64(opts.ShrinkPenalty) * float64(royaleForecastHorizon-turns+1) / float64(royaleForecastHorizon+1)
 			}
 		}
 
+		// Avoid squares that lead into pockets too small for our body.
+		// The penalty grows with the share of our body that won't fit.
+		if opts.SpacePenalty != 0 {
+			projected := s.project(m)
+			area := projected.Board.ReachableArea(projected.You.Head, s.Game)
+			if length := projected.You.length(); int32(area) < length {
+				m.Score -= float64(opts.SpacePenalty) * (1 - float64(area)/float64(length))
+			}
+		}
+
+		// Steer toward the nearest reachable food, more urgently as our
+		// health drops.  Food we can't reach before starving is ignored,
+		// as are routes through a royale hazard zone about to close in.
+		if opts.FoodSeekReward != 0 && len(s.Board.Food) > 0 {
+			urgency := float64(MaximumSnakeHealth-s.You.Health) / MaximumSnakeHealth
+			projected := s.project(m)
+			path, ok := s.pathBoard(projected.Board, forecast).ShortestPath(m, s.Board.Food, s.Game, PathOptionsForGame(s.Game))
+			if ok && int32(path.Cost) < projected.You.Health {
+				m.Score += float64(opts.FoodSeekReward) * urgency / float64(1+path.Cost)
+			}
+		}
+
+		// Prefer moves that grow the share of the board we control
+		// relative to our opponents
+		if opts.TerritoryWeight != 0 {
+			m.Score += float64(opts.TerritoryWeight) * s.project(m).territoryAdvantage()
+		}
+
+		// Consider head-to-head collisions with opponents: seek out
+		// squares a shorter opponent might move to, and avoid ones where
+		// we'd lose (only present when we had no better option)
+		if opts.ConsiderOpponentNextMove {
+			if lethal.Contains(m) {
+				m.Score -= float64(opts.HeadToHeadPenalty)
+			} else if winning.Contains(m) {
+				m.Score += float64(opts.HeadToHeadReward)
+			}
+		}
+
+		// Avoid blocking teammates by taking a square they could move to
+		if teammateMoves.Contains(m) {
+			m.Score -= float64(opts.TeammatePenalty)
+		}
+
 		scored = append(scored, m)
 	}
 
@@ -147,6 +253,97 @@ func (s Solver) score(moves CoordList, opts SolveOptions) CoordList {
 	return scored
 }
 
+// project returns a copy of the solver with our snake moved to the given
+// square on the board.  Other snakes remain where they are.
+func (s Solver) project(m Coord) Solver {
+	s.You = s.You.Project(m, s.Board, s.Game)
+	s.Board = s.Board.Clone()
+	for i, snake := range s.Board.Snakes {
+		if snake.ID == s.You.ID {
+			s.Board.Snakes[i] = s.You
+			return s
+		}
+	}
+	s.Board.Snakes = append(s.Board.Snakes, s.You)
+	return s
+}
+
+// pathBoard returns the given board as routes should be planned on it.  In
+// royale games the squares the hazard zone may engulf within the forecast
+// horizon are treated as hazards already, so routes through them cost as
+// much as if they'd been engulfed.
+func (s Solver) pathBoard(b Board, forecast ShrinkForecast) Board {
+	if s.Game.Ruleset.Name != RulesetRoyale {
+		return b
+	}
+	predicted := forecast.PredictedHazards(royaleForecastHorizon)
+	if len(predicted) == 0 {
+		return b
+	}
+	b.Hazards = append(append(CoordList{}, b.Hazards...), predicted...)
+	return b
+}
+
+// territoryAdvantage returns the difference between the share of the board
+// we control and the average share controlled by our opponents.
+func (s Solver) territoryAdvantage() float64 {
+	t := s.Board.Territory(s.Game)
+	opponents, opponentArea := 0, 0
+	for _, snake := range s.Board.Snakes {
+		if snake.ID == s.You.ID || s.You.IsTeammate(snake) {
+			continue
+		}
+		opponents++
+		opponentArea += t.Areas[snake.ID]
+	}
+	advantage := float64(t.Areas[s.You.ID])
+	if opponents > 0 {
+		advantage -= float64(opponentArea) / float64(opponents)
+	}
+	return advantage / float64(s.Board.Width*s.Board.Height)
+}
+
+// headToHeadCells returns the squares opponents could move to next, split
+// into those where a head-to-head collision would eliminate us (opponents
+// at least as long as us; ties eliminate both snakes) and those where we
+// would win it.  Teammates are not considered opponents.
+func (s Solver) headToHeadCells() (lethal CoordList, winning CoordList) {
+	lethal = CoordList{}
+	winning = CoordList{}
+	for _, snake := range s.Board.Snakes {
+		if snake.ID == s.You.ID || s.You.IsTeammate(snake) {
+			continue
+		}
+		pm, err := snake.PossibleMoves(s.Board, s.Game)
+		if err != nil {
+			// snakes next moves is not a threat -- has no valid moves
+			continue
+		}
+		if snake.length() >= s.You.length() {
+			lethal = append(lethal, pm...)
+		} else {
+			winning = append(winning, pm...)
+		}
+	}
+	return lethal, winning
+}
+
+// teammateMoves returns the squares our teammates could move to next.
+func (s Solver) teammateMoves() CoordList {
+	moves := CoordList{}
+	for _, snake := range s.Board.Snakes {
+		if !s.You.IsTeammate(snake) {
+			continue
+		}
+		pm, err := snake.PossibleMoves(s.Board, s.Game)
+		if err != nil {
+			continue
+		}
+		moves = append(moves, pm...)
+	}
+	return moves
+}
+
 func scoreSort(c CoordList) {
 	sort.Slice(c, func(i, j int) bool {
 		return c[i].Score > c[j].Score // sort descending!
@@ -164,15 +361,14 @@ func (s Solver) PickMove(possibleMoves CoordList, opts SolveOptions) (Direction,
 		if opts.UseSingleBestOption {
 			return possibleMoves[0].Direction, nil
 		}
-		if s.Game.Ruleset.Name == RulesetWrapped {
-			s.logger.Log("level", "debug", "msg", "wrapped game possible moves", "moves", fmt.Sprintf("%#v", possibleMoves), "turn", s.Turn, "me", s.You.ID)
-			return randDirection(possibleMoves.Directions()), nil
-		}
 		// If the first option here is significantly stronger than the others, use it
 		if possibleMoves[0].Score-possibleMoves[1].Score >= 4 {
 			return possibleMoves[0].Direction, nil
 		}
 		// Otherwise pick randomly from first two items
+		if s.logger != nil {
+			s.logger.Log("level", "debug", "msg", "picking between close moves", "moves", fmt.Sprintf("%#v", possibleMoves.First(2)), "turn", s.Turn, "me", s.You.ID)
+		}
 		return randDirection(possibleMoves.First(2).Directions()), nil
 	}
 }
@@ -69,6 +69,10 @@ func TestSolverPossibleMoves(t *testing.T) {
 						X: 6,
 						Y: 5,
 					},
+					{
+						X: 6,
+						Y: 5,
+					},
 				},
 			},
 			possibleDirections: []Direction{
@@ -96,6 +100,10 @@ func TestSolverPossibleMoves(t *testing.T) {
 						X: 1,
 						Y: 0,
 					},
+					{
+						X: 1,
+						Y: 0,
+					},
 				},
 			},
 			possibleDirections: []Direction{
@@ -103,6 +111,451 @@ func TestSolverPossibleMoves(t *testing.T) {
 			},
 			opts: SolveOptions{},
 		},
+		{
+			desc:  "lookahead expects tail to vacate",
+			game:  tstGame,
+			board: simpleEmptyBoard,
+			you: Battlesnake{
+				Health: 100,
+				Head: Coord{
+					X: 1,
+					Y: 0,
+				},
+				Body: CoordList{
+					{
+						X: 1,
+						Y: 0,
+					},
+					{
+						X: 1,
+						Y: 1,
+					},
+					{
+						X: 0,
+						Y: 1,
+					},
+				},
+			},
+			possibleDirections: []Direction{
+				LEFT,
+				RIGHT,
+			},
+			opts: SolveOptions{
+				Lookahead: true,
+			},
+		},
+		{
+			desc: "lookahead keeps tail in constrictor",
+			game: Game{
+				ID: "tst",
+				Ruleset: Ruleset{
+					Name: RulesetConstrictor,
+				},
+			},
+			board: simpleEmptyBoard,
+			you: Battlesnake{
+				Health: 100,
+				Head: Coord{
+					X: 1,
+					Y: 0,
+				},
+				Body: CoordList{
+					{
+						X: 1,
+						Y: 0,
+					},
+					{
+						X: 1,
+						Y: 1,
+					},
+					{
+						X: 0,
+						Y: 1,
+					},
+					{
+						X: 0,
+						Y: 1,
+					},
+				},
+			},
+			possibleDirections: []Direction{
+				RIGHT,
+			},
+			opts: SolveOptions{
+				Lookahead: true,
+			},
+		},
+		{
+			desc: "opponent tail will move out of the way",
+			game: tstGame,
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID: "opponent",
+						Head: Coord{
+							X: 3,
+							Y: 1,
+						},
+						Body: CoordList{
+							{
+								X: 3,
+								Y: 1,
+							},
+							{
+								X: 2,
+								Y: 1,
+							},
+							{
+								X: 1,
+								Y: 1,
+							},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID: "me",
+				Head: Coord{
+					X: 0,
+					Y: 1,
+				},
+				Body: CoordList{
+					{
+						X: 0,
+						Y: 1,
+					},
+					{
+						X: 0,
+						Y: 2,
+					},
+					{
+						X: 0,
+						Y: 3,
+					},
+				},
+			},
+			possibleDirections: []Direction{
+				DOWN,
+				RIGHT,
+			},
+			opts: SolveOptions{},
+		},
+		{
+			desc: "head-to-head with shorter opponent is allowed",
+			game: tstGame,
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID: "opponent",
+						Head: Coord{
+							X: 7,
+							Y: 5,
+						},
+						Body: CoordList{
+							{
+								X: 7,
+								Y: 5,
+							},
+							{
+								X: 8,
+								Y: 5,
+							},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID: "me",
+				Head: Coord{
+					X: 5,
+					Y: 5,
+				},
+				Body: CoordList{
+					{
+						X: 5,
+						Y: 5,
+					},
+					{
+						X: 5,
+						Y: 4,
+					},
+					{
+						X: 5,
+						Y: 3,
+					},
+				},
+			},
+			possibleDirections: []Direction{
+				UP,
+				LEFT,
+				RIGHT,
+			},
+			opts: SolveOptions{
+				ConsiderOpponentNextMove: true,
+			},
+		},
+		{
+			desc: "head-to-head with equal opponent is avoided",
+			game: tstGame,
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID: "opponent",
+						Head: Coord{
+							X: 7,
+							Y: 5,
+						},
+						Body: CoordList{
+							{
+								X: 7,
+								Y: 5,
+							},
+							{
+								X: 8,
+								Y: 5,
+							},
+							{
+								X: 9,
+								Y: 5,
+							},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID: "me",
+				Head: Coord{
+					X: 5,
+					Y: 5,
+				},
+				Body: CoordList{
+					{
+						X: 5,
+						Y: 5,
+					},
+					{
+						X: 5,
+						Y: 4,
+					},
+					{
+						X: 5,
+						Y: 3,
+					},
+				},
+			},
+			possibleDirections: []Direction{
+				UP,
+				LEFT,
+			},
+			opts: SolveOptions{
+				ConsiderOpponentNextMove: true,
+			},
+		},
+		{
+			desc: "head-to-head with longer opponent is a last resort",
+			game: tstGame,
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID: "opponent",
+						Head: Coord{
+							X: 2,
+							Y: 0,
+						},
+						Body: CoordList{
+							{
+								X: 2,
+								Y: 0,
+							},
+							{
+								X: 3,
+								Y: 0,
+							},
+							{
+								X: 4,
+								Y: 0,
+							},
+							{
+								X: 5,
+								Y: 0,
+							},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID: "me",
+				Head: Coord{
+					X: 0,
+					Y: 0,
+				},
+				Body: CoordList{
+					{
+						X: 0,
+						Y: 0,
+					},
+					{
+						X: 0,
+						Y: 1,
+					},
+					{
+						X: 0,
+						Y: 2,
+					},
+				},
+			},
+			possibleDirections: []Direction{
+				RIGHT,
+			},
+			opts: SolveOptions{
+				ConsiderOpponentNextMove: true,
+			},
+		},
+		{
+			desc: "squad teammate body can be passed through",
+			game: Game{
+				ID: "tst",
+				Ruleset: Ruleset{
+					Name: RulesetSquad,
+					Settings: RulesetSettings{
+						Squad: SquadSettings{
+							AllowBodyCollisions: true,
+						},
+					},
+				},
+			},
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:    "ally",
+						Squad: "red",
+						Head: Coord{
+							X: 1,
+							Y: 2,
+						},
+						Body: CoordList{
+							{
+								X: 1,
+								Y: 2,
+							},
+							{
+								X: 1,
+								Y: 1,
+							},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID:    "me",
+				Squad: "red",
+				Head: Coord{
+					X: 0,
+					Y: 1,
+				},
+				Body: CoordList{
+					{
+						X: 0,
+						Y: 1,
+					},
+					{
+						X: 0,
+						Y: 2,
+					},
+					{
+						X: 0,
+						Y: 2,
+					},
+				},
+			},
+			possibleDirections: []Direction{
+				DOWN,
+				RIGHT,
+			},
+			opts: SolveOptions{
+				ConsiderOpponentNextMove: true,
+			},
+		},
+		{
+			desc: "enemy squad body blocks",
+			game: Game{
+				ID: "tst",
+				Ruleset: Ruleset{
+					Name: RulesetSquad,
+					Settings: RulesetSettings{
+						Squad: SquadSettings{
+							AllowBodyCollisions: true,
+						},
+					},
+				},
+			},
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:    "enemy",
+						Squad: "blue",
+						Head: Coord{
+							X: 1,
+							Y: 2,
+						},
+						Body: CoordList{
+							{
+								X: 1,
+								Y: 2,
+							},
+							{
+								X: 1,
+								Y: 1,
+							},
+							{
+								X: 1,
+								Y: 0,
+							},
+						},
+					},
+				},
+			},
+			you: Battlesnake{
+				ID:    "me",
+				Squad: "red",
+				Head: Coord{
+					X: 0,
+					Y: 1,
+				},
+				Body: CoordList{
+					{
+						X: 0,
+						Y: 1,
+					},
+					{
+						X: 0,
+						Y: 2,
+					},
+					{
+						X: 0,
+						Y: 2,
+					},
+				},
+			},
+			possibleDirections: []Direction{
+				DOWN,
+			},
+			opts: SolveOptions{
+				ConsiderOpponentNextMove: true,
+			},
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
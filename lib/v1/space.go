@@ -0,0 +1,69 @@
+package v1
+
+// neverVacated marks squares that will never be freed up (such as the
+// tails of constrictor snakes).
+const neverVacated = int(^uint(0) >> 1)
+
+// index returns the position of the given Coord in a slice holding one
+// entry per square of the board.
+func (b Board) index(c Coord) int {
+	return c.Y*b.Width + c.X
+}
+
+// vacateTimes returns, for every square of the board, the number of turns
+// until that square is no longer covered by a snake body.  Squares that are
+// free already are zero.  Body segments move out of the way from the tail
+// up, so the tail tip is free after one turn and the head after as many
+// turns as the snake is long.  Stacked segments stay until the last of them
+// moves on, and nothing moves on in constrictor games.
+func (b Board) vacateTimes(g Game) []int {
+	times := make([]int, b.Width*b.Height)
+	for _, s := range b.Snakes {
+		n := len(s.Body)
+		for i, c := range s.Body {
+			if !c.WithinBounds(b) {
+				continue
+			}
+			t := n - i
+			if g.Ruleset.Name == RulesetConstrictor {
+				t = neverVacated
+			}
+			if t > times[b.index(c)] {
+				times[b.index(c)] = t
+			}
+		}
+	}
+	return times
+}
+
+// ReachableArea returns the number of squares reachable from the given
+// starting square (which is not counted itself), flooding outward one
+// square per turn.  Squares covered by snake bodies become reachable once
+// they'll have moved out of the way by the time we arrive.
+func (b Board) ReachableArea(start Coord, g Game) int {
+	if b.Width <= 0 || b.Height <= 0 || !start.WithinBounds(b) {
+		return 0
+	}
+	times := b.vacateTimes(g)
+	visited := make([]bool, b.Width*b.Height)
+	visited[b.index(start)] = true
+
+	area := 0
+	frontier := CoordList{start}
+	for turn := 1; len(frontier) > 0; turn++ {
+		next := CoordList{}
+		for _, c := range frontier {
+			for _, n := range c.Neighbors(b, g) {
+				i := b.index(n)
+				if visited[i] || times[i] > turn {
+					continue
+				}
+				visited[i] = true
+				next = append(next, n)
+			}
+		}
+		area += len(next)
+		frontier = next
+	}
+	return area
+}
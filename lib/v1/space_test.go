@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardReachableArea(t *testing.T) {
+	wall := Battlesnake{
+		ID:   "wall",
+		Head: Coord{X: 2, Y: 4},
+		Body: CoordList{
+			{X: 2, Y: 4},
+			{X: 2, Y: 3},
+			{X: 2, Y: 2},
+			{X: 2, Y: 1},
+			{X: 2, Y: 0},
+		},
+	}
+	testCases := []struct {
+		desc     string
+		board    Board
+		start    Coord
+		game     Game
+		expected int
+	}{
+		{
+			desc:     "empty board",
+			board:    simpleEmptyBoard,
+			start:    Coord{X: 5, Y: 5},
+			game:     tstGame,
+			expected: 120,
+		},
+		{
+			desc: "body vacates as we approach",
+			board: Board{
+				Height: 5,
+				Width:  5,
+				Snakes: []Battlesnake{wall},
+			},
+			start:    Coord{X: 0, Y: 0},
+			game:     tstGame,
+			expected: 24,
+		},
+		{
+			desc: "constrictor bodies never vacate",
+			board: Board{
+				Height: 5,
+				Width:  5,
+				Snakes: []Battlesnake{wall},
+			},
+			start: Coord{X: 0, Y: 0},
+			game: Game{
+				Ruleset: Ruleset{
+					Name: RulesetConstrictor,
+				},
+			},
+			expected: 9,
+		},
+		{
+			desc: "stacked tail stays an extra turn",
+			board: Board{
+				Height: 1,
+				Width:  4,
+				Snakes: []Battlesnake{
+					{
+						ID:   "a",
+						Head: Coord{X: 2, Y: 0},
+						Body: CoordList{
+							{X: 2, Y: 0},
+							{X: 1, Y: 0},
+							{X: 1, Y: 0},
+						},
+					},
+				},
+			},
+			start:    Coord{X: 0, Y: 0},
+			game:     tstGame,
+			expected: 0,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual := tC.board.ReachableArea(tC.start, tC.game)
+			assert.Equal(t, tC.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package v1
+
+// squadBodyCollisionsAllowed determines if snakes may pass through the
+// bodies of their teammates in the given game.
+func (g Game) squadBodyCollisionsAllowed() bool {
+	return g.Ruleset.Name == RulesetSquad && g.Ruleset.Settings.Squad.AllowBodyCollisions
+}
+
+// applySquadRules applies the squad settings to the board after the
+// standard eliminations have been determined, returning any additional
+// eliminations.  Eliminated snakes are tracked in the given map.
+//
+// * with shared elimination, a squad is eliminated when any member is
+// * with shared health, surviving members take the highest health in the squad
+// * with shared length, surviving members grow to the longest length in the squad
+func (b *Board) applySquadRules(g Game, eliminated map[string]bool) []Elimination {
+	settings := g.Ruleset.Settings.Squad
+	eliminations := []Elimination{}
+
+	if settings.SharedElimination {
+		fallen := map[string]string{}
+		for _, s := range b.Snakes {
+			if eliminated[s.ID] && s.Squad != "" {
+				fallen[s.Squad] = s.ID
+			}
+		}
+		for _, s := range b.Snakes {
+			by, squadFell := fallen[s.Squad]
+			if eliminated[s.ID] || s.Squad == "" || !squadFell {
+				continue
+			}
+			eliminations = append(eliminations, Elimination{ID: s.ID, Cause: EliminatedBySquad, By: by})
+			eliminated[s.ID] = true
+		}
+	}
+
+	if !settings.SharedHealth && !settings.SharedLength {
+		return eliminations
+	}
+
+	// Determine the best attributes of each squad's survivors
+	health := map[string]int32{}
+	length := map[string]int{}
+	for _, s := range b.Snakes {
+		if eliminated[s.ID] || s.Squad == "" {
+			continue
+		}
+		if s.Health > health[s.Squad] {
+			health[s.Squad] = s.Health
+		}
+		if len(s.Body) > length[s.Squad] {
+			length[s.Squad] = len(s.Body)
+		}
+	}
+	for i := range b.Snakes {
+		s := &b.Snakes[i]
+		if eliminated[s.ID] || s.Squad == "" {
+			continue
+		}
+		if settings.SharedHealth {
+			s.Health = health[s.Squad]
+		}
+		if settings.SharedLength {
+			for len(s.Body) < length[s.Squad] {
+				s.Body = append(s.Body, s.Body[len(s.Body)-1])
+			}
+			s.Length = int32(len(s.Body))
+		}
+	}
+	return eliminations
+}
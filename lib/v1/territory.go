@@ -0,0 +1,95 @@
+package v1
+
+// Territory describes how many squares of the board each snake controls:
+// the squares it can reach before any other snake.
+type Territory struct {
+	// Areas maps snake IDs to the number of squares they control
+	Areas map[string]int
+	// Contested is the number of squares reached first by more than one
+	// snake of the same length at the same time
+	Contested int
+}
+
+// Territory computes a Voronoi partition of the board using a breadth
+// first search from every snake's head at once.  Each square belongs to
+// the snake that can reach it first; when snakes arrive at the same time
+// the longest snake claims it, and ties between equally long snakes leave
+// the square contested.  Snake bodies block the search until they'll have
+// moved out of the way.
+func (b Board) Territory(g Game) Territory {
+	t := Territory{
+		Areas: map[string]int{},
+	}
+	if b.Width <= 0 || b.Height <= 0 {
+		return t
+	}
+
+	const (
+		unclaimed = -1
+		contested = -2
+	)
+	times := b.vacateTimes(g)
+	owner := make([]int, b.Width*b.Height)
+	for i := range owner {
+		owner[i] = unclaimed
+	}
+
+	frontiers := make([]CoordList, len(b.Snakes))
+	for i, s := range b.Snakes {
+		t.Areas[s.ID] = 0
+		if len(s.Body) == 0 || !s.Head.WithinBounds(b) {
+			continue
+		}
+		owner[b.index(s.Head)] = i
+		frontiers[i] = CoordList{s.Head}
+	}
+
+	for turn := 1; ; turn++ {
+		// Determine who reaches each square first this turn
+		claims := map[int]territoryClaim{}
+		squares := map[int]Coord{}
+		for i, frontier := range frontiers {
+			length := b.Snakes[i].length()
+			for _, c := range frontier {
+				for _, n := range c.Neighbors(b, g) {
+					ni := b.index(n)
+					if owner[ni] != unclaimed || times[ni] > turn {
+						continue
+					}
+					squares[ni] = n
+					prev, claimed := claims[ni]
+					switch {
+					case !claimed || length > prev.length:
+						claims[ni] = territoryClaim{owner: i, length: length}
+					case length == prev.length && prev.owner != i:
+						claims[ni] = territoryClaim{owner: contested, length: length}
+					}
+				}
+			}
+		}
+		if len(claims) == 0 {
+			break
+		}
+
+		// Record the claims and expand each frontier
+		next := make([]CoordList, len(b.Snakes))
+		for ni, claim := range claims {
+			i := claim.owner
+			owner[ni] = i
+			if i == contested {
+				t.Contested++
+				continue
+			}
+			t.Areas[b.Snakes[i].ID]++
+			next[i] = append(next[i], squares[ni])
+		}
+		frontiers = next
+	}
+	return t
+}
+
+// territoryClaim is the snake that reached a square first, and its length.
+type territoryClaim struct {
+	owner  int
+	length int32
+}
@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoardTerritory(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		board    Board
+		expected Territory
+	}{
+		{
+			desc: "single snake controls the board",
+			board: Board{
+				Height: 11,
+				Width:  11,
+				Snakes: []Battlesnake{
+					{
+						ID:   "a",
+						Head: Coord{X: 5, Y: 5},
+						Body: CoordList{
+							{X: 5, Y: 5},
+						},
+					},
+				},
+			},
+			expected: Territory{
+				Areas: map[string]int{
+					"a": 120,
+				},
+			},
+		},
+		{
+			desc: "equal snakes split the board",
+			board: Board{
+				Height: 1,
+				Width:  7,
+				Snakes: []Battlesnake{
+					{
+						ID:   "a",
+						Head: Coord{X: 0, Y: 0},
+						Body: CoordList{
+							{X: 0, Y: 0},
+						},
+					},
+					{
+						ID:   "b",
+						Head: Coord{X: 6, Y: 0},
+						Body: CoordList{
+							{X: 6, Y: 0},
+						},
+					},
+				},
+			},
+			expected: Territory{
+				Areas: map[string]int{
+					"a": 2,
+					"b": 2,
+				},
+				Contested: 1,
+			},
+		},
+		{
+			desc: "longer snake wins ties",
+			board: Board{
+				Height: 1,
+				Width:  7,
+				Snakes: []Battlesnake{
+					{
+						ID:   "a",
+						Head: Coord{X: 0, Y: 0},
+						Body: CoordList{
+							{X: 0, Y: 0},
+						},
+					},
+					{
+						ID:     "b",
+						Head:   Coord{X: 6, Y: 0},
+						Length: 2,
+						Body: CoordList{
+							{X: 6, Y: 0},
+							{X: 6, Y: 0},
+						},
+					},
+				},
+			},
+			expected: Territory{
+				Areas: map[string]int{
+					"a": 2,
+					"b": 3,
+				},
+			},
+		},
+		{
+			desc: "bodies wall off territory",
+			board: Board{
+				Height: 3,
+				Width:  3,
+				Snakes: []Battlesnake{
+					{
+						ID:   "a",
+						Head: Coord{X: 0, Y: 0},
+						Body: CoordList{
+							{X: 0, Y: 0},
+						},
+					},
+					{
+						ID:   "b",
+						Head: Coord{X: 1, Y: 2},
+						Body: CoordList{
+							{X: 1, Y: 2},
+							{X: 1, Y: 1},
+							{X: 1, Y: 0},
+							{X: 1, Y: 0},
+						},
+					},
+				},
+			},
+			expected: Territory{
+				Areas: map[string]int{
+					"a": 1,
+					"b": 6,
+				},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			actual := tC.board.Territory(tstGame)
+			assert.Equal(t, tC.expected, actual)
+		})
+	}
+}